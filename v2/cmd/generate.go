@@ -38,8 +38,8 @@ import (
 
 var (
 	defaultHeaderModule  = builtin.Header
-	defaultGlobalModules = []module.Module{builtin.Interface}
-	defaultTypeModules   = []module.Module{builtin.Type, builtin.Operation}
+	defaultGlobalModules = []module.Module{builtin.Interface, builtin.ChangeStream}
+	defaultTypeModules   = []module.Module{builtin.Type, builtin.Validate, builtin.Operation, builtin.ForeignKey}
 )
 
 // generateCmdOption is the type that specifies the command line arguments.
@@ -68,12 +68,20 @@ type generateCmdOption struct {
 	// Tags is the list of build tags to add to generated Go files.
 	Tags string
 
-	// DDLFilepath is the filepath of the ddl file.
+	// DDLFilepath is the filepath of the ddl file, a directory of ddl files or a glob pattern.
 	DDLFilepath string
 
 	// FromDDL indicates generating from ddl flie or not.
 	FromDDL bool
 
+	// Source is the schema source of the database, information-schema or
+	// admin-ddl.
+	Source string
+
+	// FromSnapshot indicates generating from a schema snapshot file or not.
+	// The snapshot file is specified by DDLFilepath.
+	FromSnapshot bool
+
 	// IgnoreFields allows the user to specify field names which should not be
 	// handled by yo in the generated code.
 	IgnoreFields []string
@@ -82,6 +90,10 @@ type generateCmdOption struct {
 	// handled by yo in the generated code.
 	IgnoreTables []string
 
+	// Schemas allows the user to specify schema names which should be
+	// handled by yo in the generated code. All schemas are handled if empty.
+	Schemas []string
+
 	// Path to config file
 	ConfigFile string
 
@@ -98,7 +110,37 @@ type generateCmdOption struct {
 	// UseLegacyIndexModule uses legacy index module instead of the default index module
 	UseLegacyIndexModule bool
 
-	baseDir string
+	// ValidateMutations makes mutation methods validate the values before building a mutation
+	ValidateMutations bool
+
+	// SingleFile writes all generated code into a single file. The file is
+	// Out if Out is a file, otherwise <Package><Suffix> under Out.
+	SingleFile bool
+
+	// FilenameStrategy decides the names of the generated files. It
+	// overrides the config if not empty.
+	FilenameStrategy string
+
+	// Timeout is the timeout of the command such as loading the schema of
+	// the database.
+	Timeout time.Duration
+
+	// Check verifies the generated files are up to date instead of writing
+	// them. It prints the diffs and fails if they are not.
+	Check bool
+
+	// Prune removes the files generated by yo in the output directory which
+	// are not generated anymore.
+	Prune bool
+
+	// Plugins are the paths of the external generator plugins.
+	Plugins []string
+
+	// PluginParameters are the parameters passed to the plugins.
+	PluginParameters []string
+
+	baseDir  string
+	filename string
 }
 
 var (
@@ -118,14 +160,32 @@ var (
   # Generate models from DDL under the models directory with custom types
   yo generate schema.sql --from-ddl -o models --custom-types-file custom_column_types.yml
 
+  # Generate models from a directory of migration files under the models directory
+  yo generate migrations --from-ddl -o models
+
+  # Generate models from a schema snapshot under the models directory
+  yo generate schema.json --from-snapshot -o models
+
   # Generate models under the models directory
   yo generate $SPANNER_PROJECT_NAME $SPANNER_INSTANCE_NAME $SPANNER_DATABASE_NAME -o models
 
+  # Generate models from the DDL of the database, which only requires the permission to get DDL
+  yo generate $SPANNER_PROJECT_NAME $SPANNER_INSTANCE_NAME $SPANNER_DATABASE_NAME --source admin-ddl -o models
+
   # Generate models under the models directory with custom types
   yo generate $SPANNER_PROJECT_NAME $SPANNER_INSTANCE_NAME $SPANNER_DATABASE_NAME -o models --custom-types-file custom_column_types.yml
+
+  # Check the models under the models directory are up to date with DDL
+  yo generate schema.sql --from-ddl -o models --check
+
+  # Generate models under the models directory and remove the files of dropped tables
+  yo generate schema.sql --from-ddl -o models --prune
+
+  # Generate models and the code generated by a plugin under the models directory
+  yo generate schema.sql --from-ddl -o models --plugin ./yo-gen-repository
 `,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+			ctx, cancel := context.WithTimeout(context.Background(), generateCmdOpts.Timeout)
 			defer cancel()
 
 			cfg, err := config.Load(generateCmdOpts.ConfigFile)
@@ -148,12 +208,13 @@ var (
 				if err != nil {
 					return fmt.Errorf("failed to create spanner loader: %v", err)
 				}
-			} else {
-				spannerClient, err := connectSpanner(ctx, generateCmdOpts.Project, generateCmdOpts.Instance, generateCmdOpts.Database)
+			} else if generateCmdOpts.FromSnapshot {
+				source, err = loader.NewSnapshotSource(generateCmdOpts.DDLFilepath)
 				if err != nil {
-					return fmt.Errorf("failed to connect spanner: %v", err)
+					return fmt.Errorf("failed to create spanner loader: %v", err)
 				}
-				source, err = loader.NewInformationSchemaSource(spannerClient)
+			} else {
+				source, err = connectSchemaSource(ctx, generateCmdOpts.Source, generateCmdOpts.Project, generateCmdOpts.Instance, generateCmdOpts.Database)
 				if err != nil {
 					return fmt.Errorf("failed to create spanner loader: %v", err)
 				}
@@ -163,6 +224,7 @@ var (
 				Config:       cfg,
 				IgnoreTables: generateCmdOpts.IgnoreTables,
 				IgnoreFields: generateCmdOpts.IgnoreFields,
+				Schemas:      generateCmdOpts.Schemas,
 			})
 
 			// load defs into type map
@@ -171,6 +233,15 @@ var (
 				return fmt.Errorf("error: %v", err)
 			}
 
+			filenameStrategy := cfg.FilenameStrategy
+			if generateCmdOpts.FilenameStrategy != "" {
+				filenameStrategy = generateCmdOpts.FilenameStrategy
+			}
+			filenameFunc, err := generator.NewFilenameStrategy(filenameStrategy)
+			if err != nil {
+				return err
+			}
+
 			headerModule, globalModules, typeModules := decideModules(&generateCmdOpts)
 
 			g := generator.NewGenerator(typeLoader, inflector, generator.GeneratorOption{
@@ -180,11 +251,20 @@ var (
 				BaseDir:        generateCmdOpts.baseDir,
 				DisableFormat:  generateCmdOpts.DisableFormat,
 
+				ValidateMutations: generateCmdOpts.ValidateMutations,
+				SingleFile:        generateCmdOpts.SingleFile,
+				Filename:          generateCmdOpts.filename,
+				FilenameStrategy:  filenameFunc,
+				Check:             generateCmdOpts.Check,
+				Prune:             generateCmdOpts.Prune,
+				Plugins:           generateCmdOpts.Plugins,
+				PluginParameters:  generateCmdOpts.PluginParameters,
+
 				HeaderModule:  headerModule,
 				GlobalModules: globalModules,
 				TypeModules:   typeModules,
 			})
-			if err := g.Generate(schema); err != nil {
+			if err := g.Generate(ctx, schema); err != nil {
 				return fmt.Errorf("error: %v", err)
 			}
 
@@ -195,12 +275,15 @@ var (
 
 func init() {
 	generateCmd.Flags().StringVarP(&generateCmdOpts.ConfigFile, "config", "c", "", "path to Yo config file")
-	generateCmd.Flags().BoolVar(&generateCmdOpts.FromDDL, "from-ddl", false, "toggle using DDL file")
+	generateCmd.Flags().BoolVar(&generateCmdOpts.FromDDL, "from-ddl", false, "toggle using DDL file, directory or glob")
+	generateCmd.Flags().BoolVar(&generateCmdOpts.FromSnapshot, "from-snapshot", false, "toggle using schema snapshot file")
+	generateCmd.Flags().StringVar(&generateCmdOpts.Source, "source", sourceInformationSchema, "schema source of the database, information-schema or admin-ddl")
 	generateCmd.Flags().StringVarP(&generateCmdOpts.Out, "out", "o", "", "output path or file name")
 	generateCmd.Flags().StringVar(&generateCmdOpts.Suffix, "suffix", defaultSuffix, "output file suffix")
 	generateCmd.Flags().StringVarP(&generateCmdOpts.Package, "package", "p", "", "package name used in generated Go code")
 	generateCmd.Flags().StringArrayVar(&generateCmdOpts.IgnoreFields, "ignore-fields", nil, "fields to exclude from the generated Go code types")
 	generateCmd.Flags().StringArrayVar(&generateCmdOpts.IgnoreTables, "ignore-tables", nil, "tables to exclude from the generated Go code types")
+	generateCmd.Flags().StringArrayVar(&generateCmdOpts.Schemas, "schema", nil, "schemas to include in the generated Go code types (an empty name means the default schema)")
 	generateCmd.Flags().StringVar(&generateCmdOpts.Tags, "tags", "", "build tags to add to a package header")
 	generateCmd.Flags().BoolVar(&generateCmdOpts.DisableDefaultModules, "disable-default-modules", false, "disable the default modules for code generation")
 	generateCmd.Flags().BoolVar(&generateCmdOpts.DisableFormat, "disable-format", false, "disable to apply gofmt to generated files")
@@ -208,6 +291,14 @@ func init() {
 	generateCmd.Flags().StringArrayVar(&generateCmdOpts.AdditionalGlobalModules, "global-module", nil, "add a user defined module to global modules")
 	generateCmd.Flags().StringArrayVar(&generateCmdOpts.AdditionalTypeModules, "type-module", nil, "add a user defined module to type modules")
 	generateCmd.Flags().BoolVar(&generateCmdOpts.UseLegacyIndexModule, "use-legacy-index-module", false, "use legacy index func name")
+	generateCmd.Flags().BoolVar(&generateCmdOpts.ValidateMutations, "validate-mutations", false, "validate values by Validate in mutation methods")
+	generateCmd.Flags().BoolVar(&generateCmdOpts.SingleFile, "single-file", false, "write all generated code into a single file")
+	generateCmd.Flags().BoolVar(&generateCmdOpts.Check, "check", false, "check the generated files are up to date and print the diffs instead of writing them")
+	generateCmd.Flags().BoolVar(&generateCmdOpts.Prune, "prune", false, "remove the files generated by yo in the output directory which are not generated anymore")
+	generateCmd.Flags().StringArrayVar(&generateCmdOpts.Plugins, "plugin", nil, "add an external generator plugin")
+	generateCmd.Flags().StringArrayVar(&generateCmdOpts.PluginParameters, "plugin-opt", nil, "parameters passed to the plugins")
+	generateCmd.Flags().DurationVar(&generateCmdOpts.Timeout, "timeout", defaultTimeout, "timeout of the command such as loading the schema of the database")
+	generateCmd.Flags().StringVar(&generateCmdOpts.FilenameStrategy, "filename-strategy", "", "file names of generated code: snake, lower, table or a template (default snake)")
 
 	helpFn := generateCmd.HelpFunc()
 	generateCmd.SetHelpFunc(func(cmd *cobra.Command, args []string) {
@@ -219,6 +310,13 @@ func init() {
 }
 
 func processGenerateCmdOption(opts *generateCmdOption, argv []string) error {
+	if opts.FromDDL && opts.FromSnapshot {
+		return fmt.Errorf("--from-ddl and --from-snapshot cannot be used together")
+	}
+	if err := validateSource(opts.Source); err != nil {
+		return err
+	}
+
 	if len(argv) == 3 {
 		opts.Project = argv[0]
 		opts.Instance = argv[1]
@@ -227,7 +325,12 @@ func processGenerateCmdOption(opts *generateCmdOption, argv []string) error {
 		opts.DDLFilepath = argv[0]
 	}
 
+	if opts.SingleFile && opts.FilenameStrategy != "" {
+		return fmt.Errorf("--single-file and --filename-strategy cannot be used together")
+	}
+
 	path := ""
+	filename := ""
 
 	cwd, err := os.Getwd()
 	if err != nil {
@@ -240,14 +343,21 @@ func processGenerateCmdOption(opts *generateCmdOption, argv []string) error {
 	} else {
 		// determine what to do with Out
 		fi, err := os.Stat(opts.Out)
-		if err != nil {
-			return err
-		}
-		if fi.IsDir() {
+		switch {
+		case err == nil && fi.IsDir():
 			// out is directory
 			path = opts.Out
-		} else {
-			return fmt.Errorf("output path must be a directory")
+		case opts.SingleFile && (err == nil || os.IsNotExist(err)):
+			// out is a file in an existing directory
+			path = filepath.Dir(opts.Out)
+			filename = filepath.Base(opts.Out)
+			if fi, err := os.Stat(path); err != nil || !fi.IsDir() {
+				return fmt.Errorf("output directory %s must exist", path)
+			}
+		case err != nil:
+			return err
+		default:
+			return fmt.Errorf("output path must be a directory when not writing to a single file")
 		}
 	}
 
@@ -261,7 +371,13 @@ func processGenerateCmdOption(opts *generateCmdOption, argv []string) error {
 		opts.Package = pathpkg.Base(path)
 	}
 
+	// determine the file name of a single file
+	if filename == "" {
+		filename = opts.Package + opts.Suffix
+	}
+
 	opts.baseDir = path
+	opts.filename = filename
 
 	return nil
 }
@@ -22,13 +22,15 @@ package cmd
 import (
 	"runtime/debug"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 )
 
 const (
-	defaultSuffix = ".yo.go"
-	exampleUsage  = `
+	defaultSuffix  = ".yo.go"
+	defaultTimeout = 60 * time.Second
+	exampleUsage   = `
   # Generate models from ddl under models directory
   yo generate schema.sql --from-ddl -o models
 
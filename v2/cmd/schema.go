@@ -0,0 +1,142 @@
+// Copyright (c) 2020 Mercari, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"go.mercari.io/yo/v2/loader"
+)
+
+type schemaDumpCmdOption struct {
+	// FromDDL indicates dumping from ddl file or not.
+	FromDDL bool
+
+	// Source is the schema source of the database, information-schema or
+	// admin-ddl.
+	Source string
+
+	// Out is the output file name. The snapshot is written to stdout if empty.
+	Out string
+
+	// Format is the snapshot format, json or yaml. If not specified, it is
+	// decided by the extension of Out.
+	Format string
+
+	// Timeout is the timeout of loading the schema of the database.
+	Timeout time.Duration
+}
+
+var (
+	schemaDumpCmdOpts = schemaDumpCmdOption{}
+	schemaCmd         = &cobra.Command{
+		Use:   "schema",
+		Short: "yo schema manages schema snapshots.",
+	}
+	schemaDumpCmd = &cobra.Command{
+		Use:   "dump",
+		Short: "yo schema dump writes a snapshot of the schema to generate code without access to the database.",
+		Args: func(cmd *cobra.Command, args []string) error {
+			if l := len(args); l != 1 && l != 3 {
+				return fmt.Errorf("must specify 1 or 3 arguments")
+			}
+			return nil
+		},
+		Example: `  # Dump the schema of the database to schema.json
+  yo schema dump $SPANNER_PROJECT_NAME $SPANNER_INSTANCE_NAME $SPANNER_DATABASE_NAME -o schema.json
+
+  # Dump the schema defined by DDL to stdout as YAML
+  yo schema dump schema.sql --from-ddl --format yaml
+`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, cancel := context.WithTimeout(context.Background(), schemaDumpCmdOpts.Timeout)
+			defer cancel()
+
+			format := schemaDumpCmdOpts.Format
+			switch format {
+			case "":
+				format = loader.SnapshotFormat(schemaDumpCmdOpts.Out)
+			case "json", "yaml":
+			default:
+				return fmt.Errorf("unknown format %s: must be json or yaml", format)
+			}
+
+			var source loader.SchemaSource
+			var err error
+			if schemaDumpCmdOpts.FromDDL {
+				if len(args) != 1 {
+					return fmt.Errorf("must specify a DDL file with --from-ddl")
+				}
+				source, err = loader.NewSchemaParserSource(args[0])
+				if err != nil {
+					return fmt.Errorf("failed to create spanner loader: %v", err)
+				}
+			} else {
+				if len(args) != 3 {
+					return fmt.Errorf("must specify project, instance and database")
+				}
+				source, err = connectSchemaSource(ctx, schemaDumpCmdOpts.Source, args[0], args[1], args[2])
+				if err != nil {
+					return fmt.Errorf("failed to create spanner loader: %v", err)
+				}
+			}
+
+			snapshot, err := loader.DumpSnapshot(source)
+			if err != nil {
+				return fmt.Errorf("error: %v", err)
+			}
+
+			if schemaDumpCmdOpts.Out == "" {
+				return snapshot.Write(os.Stdout, format)
+			}
+
+			f, err := os.Create(schemaDumpCmdOpts.Out)
+			if err != nil {
+				return err
+			}
+			if err := snapshot.Write(f, format); err != nil {
+				_ = f.Close()
+				return err
+			}
+
+			// the snapshot may not be written until the file is closed
+			if err := f.Close(); err != nil {
+				return fmt.Errorf("failed to write the snapshot: %v", err)
+			}
+			return nil
+		},
+	}
+)
+
+func init() {
+	schemaDumpCmd.Flags().BoolVar(&schemaDumpCmdOpts.FromDDL, "from-ddl", false, "toggle using DDL file, directory or glob")
+	schemaDumpCmd.Flags().StringVar(&schemaDumpCmdOpts.Source, "source", sourceInformationSchema, "schema source of the database, information-schema or admin-ddl")
+	schemaDumpCmd.Flags().StringVarP(&schemaDumpCmdOpts.Out, "out", "o", "", "output file name (default stdout)")
+	schemaDumpCmd.Flags().StringVar(&schemaDumpCmdOpts.Format, "format", "", "snapshot format, json or yaml (default decided by the extension of the output file, or json)")
+
+	schemaDumpCmd.Flags().DurationVar(&schemaDumpCmdOpts.Timeout, "timeout", defaultTimeout, "timeout of loading the schema of the database")
+
+	schemaCmd.AddCommand(schemaDumpCmd)
+	rootCmd.AddCommand(schemaCmd)
+}
@@ -24,6 +24,18 @@ import (
 	"fmt"
 
 	"cloud.google.com/go/spanner"
+	database "cloud.google.com/go/spanner/admin/database/apiv1"
+	"go.mercari.io/yo/v2/loader"
+)
+
+const (
+	// sourceInformationSchema reads the schema from the information schema
+	// by queries.
+	sourceInformationSchema = "information-schema"
+
+	// sourceAdminDDL reads the schema from the DDL statements returned by
+	// GetDatabaseDdl of the Database Admin API.
+	sourceAdminDDL = "admin-ddl"
 )
 
 func connectSpanner(ctx context.Context, project, instance, database string) (*spanner.Client, error) {
@@ -36,3 +48,42 @@ func connectSpanner(ctx context.Context, project, instance, database string) (*s
 
 	return spannerClient, nil
 }
+
+// validateSource returns an error if source is not a known schema source of
+// a database.
+func validateSource(source string) error {
+	switch source {
+	case sourceInformationSchema, sourceAdminDDL:
+		return nil
+	default:
+		return fmt.Errorf("unknown source %s: must be %s or %s", source, sourceInformationSchema, sourceAdminDDL)
+	}
+}
+
+// connectSchemaSource creates a SchemaSource which reads the schema of the
+// database from source. The schema is loaded at once, so the clients are
+// closed before returning.
+func connectSchemaSource(ctx context.Context, source, project, instance, databaseID string) (loader.SchemaSource, error) {
+	if err := validateSource(source); err != nil {
+		return nil, err
+	}
+
+	if source == sourceAdminDDL {
+		adminClient, err := database.NewDatabaseAdminClient(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to connect spanner: %v", err)
+		}
+		defer adminClient.Close()
+
+		databaseName := fmt.Sprintf("projects/%s/instances/%s/databases/%s", project, instance, databaseID)
+		return loader.NewAdminDDLSource(ctx, adminClient, databaseName)
+	}
+
+	spannerClient, err := connectSpanner(ctx, project, instance, databaseID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect spanner: %v", err)
+	}
+	defer spannerClient.Close()
+
+	return loader.NewInformationSchemaSource(ctx, spannerClient)
+}
@@ -22,21 +22,139 @@ package config
 type Config struct {
 	Tables      []Table      `yaml:"tables"`
 	Inflections []Inflection `yaml:"inflections"`
+	ProtoTypes  []ProtoType  `yaml:"protoTypes"`
+	Types       []Type       `yaml:"types"`
+
+	// Dialect is the dialect of the database, GOOGLE_STANDARD_SQL or
+	// POSTGRESQL. It is used when the schema source cannot detect it.
+	Dialect string `yaml:"dialect"`
+
+	// IncludeTables is the list of patterns of the tables to load. All
+	// tables are loaded if it is empty. A pattern is a glob such as Tmp*,
+	// or a regular expression enclosed in slashes such as /^Tmp[0-9]+$/.
+	IncludeTables []string `yaml:"includeTables"`
+
+	// ExcludeTables is the list of patterns of the tables not to load. It
+	// is applied after IncludeTables.
+	ExcludeTables []string `yaml:"excludeTables"`
+
+	// Tags defines the struct tags of the fields of the generated structs.
+	Tags Tags `yaml:"tags"`
+
+	// FilenameStrategy decides the names of the generated files: snake,
+	// lower, table or a template. The --filename-strategy flag overrides it.
+	FilenameStrategy string `yaml:"filenameStrategy"`
+
+	// CommitTimestamp makes mutations write spanner.CommitTimestamp into the
+	// non-primary key columns with allow_commit_timestamp=true. The
+	// commitTimestamp of a column overrides it.
+	CommitTimestamp bool `yaml:"commitTimestamp"`
+}
+
+// Tags represents definitions of the struct tags of fields
+type Tags struct {
+	// JSONNaming is the naming strategy of the json tags: column, snake_case,
+	// lowerCamelCase or field. column, the default, uses the column name as
+	// it is, and field uses the Go field name.
+	JSONNaming string `yaml:"jsonNaming"`
+
+	// JSONOmitEmpty appends omitempty to the json tags.
+	JSONOmitEmpty bool `yaml:"jsonOmitEmpty"`
+
+	// Extra is the list of the tags following the spanner and json tags.
+	Extra []Tag `yaml:"extra"`
+}
+
+// Tag represents a definition of a struct tag
+type Tag struct {
+	Key string `yaml:"key"`
+
+	// Value is a text/template of the tag value executed with the
+	// models.Field of a column, such as {{ snake .ColumnName }}. The tag is
+	// omitted if the result is empty.
+	Value string `yaml:"value"`
 }
 
-// Table represents custom type definitions
+// Table represents custom definitions of a table
 type Table struct {
 	Name    string   `yaml:"name"`
 	Columns []Column `yaml:"columns"`
+
+	// TypeName overrides the Go type name of the table.
+	TypeName string `yaml:"typeName"`
+
+	// IgnoreColumns is the list of columns not to be handled in the
+	// generated code.
+	IgnoreColumns []string `yaml:"ignoreColumns"`
+
+	Indexes []Index `yaml:"indexes"`
 }
 
-// Column represents custom type definitions
+// Column represents custom definitions of a column
 type Column struct {
 	Name       string `yaml:"name"`
 	CustomType string `yaml:"customType"`
+
+	// FieldName overrides the Go field name of the column.
+	FieldName string `yaml:"fieldName"`
+
+	// Tags overrides the values of the struct tags of the field by the
+	// keys. An empty value omits the tag.
+	Tags map[string]string `yaml:"tags"`
+
+	// CommitTimestamp specifies whether mutations write spanner.CommitTimestamp
+	// into the column. It defaults to the commitTimestamp of the config for a
+	// non-primary key column with allow_commit_timestamp=true.
+	CommitTimestamp *bool `yaml:"commitTimestamp"`
+}
+
+// Index represents custom definitions of an index
+type Index struct {
+	Name string `yaml:"name"`
+
+	// FuncName overrides the name of the functions of the index following
+	// Find and Read, such as SingersByName for FindSingersByName.
+	FuncName string `yaml:"funcName"`
 }
 
 type Inflection struct {
 	Singular string `yaml:"singular"`
 	Plural   string `yaml:"plural"`
 }
+
+// ProtoType represents a Go type definition of a PROTO or ENUM type
+type ProtoType struct {
+	// Name is the fully qualified name of the proto message or enum such as
+	// examples.music.Singer.
+	Name string `yaml:"name"`
+
+	// GoType is the Go type qualified by the package name such as
+	// musicpb.Singer.
+	GoType string `yaml:"goType"`
+
+	// ImportPath is the import path of the Go package of GoType.
+	ImportPath string `yaml:"importPath"`
+
+	// Enum specifies the type is a proto enum. It is required to tell enums
+	// from messages in DDL, which does not distinguish them.
+	Enum bool `yaml:"enum"`
+}
+
+// Type represents a Go type definition of a Spanner type
+type Type struct {
+	// SpannerType is the name of a Spanner type such as UUID. It overrides
+	// the builtin mapping of the type, and an ARRAY of the type is mapped
+	// to a slice of GoType.
+	SpannerType string `yaml:"spannerType"`
+
+	// GoType is the Go type of a NOT NULL column such as uuid.UUID.
+	GoType string `yaml:"goType"`
+
+	// NullGoType is the Go type of a nullable column such as
+	// spanner.NullUUID. It defaults to GoType.
+	NullGoType string `yaml:"nullGoType"`
+
+	// ImportPath is the import path of the Go package of GoType. It is not
+	// required if goimports can resolve the package.
+	ImportPath string `yaml:"importPath"`
+}
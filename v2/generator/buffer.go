@@ -22,10 +22,12 @@ package generator
 import (
 	"bytes"
 	"fmt"
+	"io"
 	"os"
 	"sort"
 	"strings"
 
+	"github.com/pmezard/go-difflib/difflib"
 	"golang.org/x/tools/imports"
 )
 
@@ -43,6 +45,11 @@ type FileBuffer struct {
 	Header []byte
 	Chunks []*TBuf
 
+	// MergeImports moves the imports of the header and the chunks into one
+	// import declaration after the package clause. It is required for a
+	// file of several types, whose chunks have their own imports.
+	MergeImports bool
+
 	TempDir      string
 	TempFilePath string
 }
@@ -67,16 +74,22 @@ func (f *FileBuffer) WriteTempFile() error {
 }
 
 func (f *FileBuffer) writeChunks(file *os.File) error {
+	chunks := TBufSlice(f.Chunks)
+
+	// sort chunks. The chunks of the same name are kept in the order of
+	// the modules
+	sort.Stable(chunks)
+
+	header := f.Header
+	if f.MergeImports {
+		header = f.mergeImports(chunks)
+	}
+
 	// write a header to the file
-	if _, err := file.Write(f.Header); err != nil {
+	if _, err := file.Write(header); err != nil {
 		return err
 	}
 
-	chunks := TBufSlice(f.Chunks)
-
-	// sort chunks
-	sort.Sort(chunks)
-
 	// write chunks to the file in order
 	for i, chunk := range chunks {
 		// add new line between chunks
@@ -98,6 +111,82 @@ func (f *FileBuffer) writeChunks(file *os.File) error {
 	return nil
 }
 
+// mergeImports removes the imports from the header and the chunks, and
+// returns the header with an import declaration of all of them.
+func (f *FileBuffer) mergeImports(chunks []*TBuf) []byte {
+	var specs []string
+	seen := make(map[string]bool)
+	add := func(imports []string) {
+		for _, spec := range imports {
+			if !seen[spec] {
+				seen[spec] = true
+				specs = append(specs, spec)
+			}
+		}
+	}
+
+	imports, header := splitImports(f.Header)
+	add(imports)
+	for _, chunk := range chunks {
+		imports, rest := splitImports(chunk.Buf.Bytes())
+		add(imports)
+		chunk.Buf = bytes.NewBuffer(rest)
+	}
+
+	if len(specs) == 0 {
+		return header
+	}
+
+	decl := new(bytes.Buffer)
+	decl.WriteString("\nimport (\n")
+	for _, spec := range specs {
+		fmt.Fprintf(decl, "\t%s\n", spec)
+	}
+	decl.WriteString(")\n")
+
+	// put the imports after the package clause
+	lines := bytes.SplitAfter(header, []byte("\n"))
+	for i, line := range lines {
+		if bytes.HasPrefix(line, []byte("package ")) {
+			res := bytes.Join(lines[:i+1], nil)
+			if !bytes.HasSuffix(res, []byte("\n")) {
+				res = append(res, '\n')
+			}
+			res = append(res, decl.Bytes()...)
+			return append(res, bytes.Join(lines[i+1:], nil)...)
+		}
+	}
+
+	return append(header, decl.Bytes()...)
+}
+
+// splitImports splits the import declarations at the top level from src. It
+// returns the import specs such as "fmt" and the rest of src.
+func splitImports(src []byte) ([]string, []byte) {
+	var specs []string
+	rest := new(bytes.Buffer)
+	inDecl := false
+	for _, line := range bytes.SplitAfter(src, []byte("\n")) {
+		s := string(line)
+		switch {
+		case inDecl:
+			if strings.HasPrefix(s, ")") {
+				inDecl = false
+			} else if spec := strings.TrimSpace(s); spec != "" && !strings.HasPrefix(spec, "//") {
+				specs = append(specs, spec)
+			}
+		case strings.HasPrefix(s, "import ("):
+			inDecl = true
+		case strings.HasPrefix(s, "import "):
+			specs = append(specs, strings.TrimSpace(strings.TrimPrefix(s, "import ")))
+		default:
+			rest.WriteString(s)
+		}
+	}
+
+	return specs, rest.Bytes()
+}
+
 func (f *FileBuffer) Postprocess(disableFormat bool) error {
 	if !disableFormat {
 		// run gofmt for the temp file
@@ -129,6 +218,45 @@ func (f *FileBuffer) Finalize() error {
 	return nil
 }
 
+// Check compares the generated file with the file on disk instead of putting
+// it, and writes the unified diff to w. It reports whether the file on disk
+// is up to date.
+func (f *FileBuffer) Check(w io.Writer) (bool, error) {
+	generated, err := os.ReadFile(f.TempFilePath)
+	if err != nil {
+		return false, fmt.Errorf("failed to read temp file for %s: %v", f.BaseName, err)
+	}
+
+	fromFile := f.FileName
+	current, err := os.ReadFile(f.FileName)
+	if os.IsNotExist(err) {
+		fromFile = "/dev/null"
+	} else if err != nil {
+		return false, fmt.Errorf("failed to read file for %s: %v", f.BaseName, err)
+	}
+
+	if bytes.Equal(current, generated) {
+		return true, nil
+	}
+
+	diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(string(current)),
+		B:        difflib.SplitLines(string(generated)),
+		FromFile: fromFile,
+		ToFile:   f.FileName,
+		Context:  3,
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to diff file for %s: %v", f.BaseName, err)
+	}
+
+	if _, err := io.WriteString(w, diff); err != nil {
+		return false, err
+	}
+
+	return false, nil
+}
+
 // TBuf is to hold the executed templates.
 type TBuf struct {
 	Name string
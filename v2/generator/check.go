@@ -0,0 +1,387 @@
+// Copyright (c) 2020 Mercari, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package generator
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/cloudspannerecosystem/memefish"
+	"github.com/cloudspannerecosystem/memefish/ast"
+	"go.mercari.io/yo/v2/models"
+)
+
+// Go operator precedences used to parenthesize translated expressions.
+const (
+	precOr = iota + 1
+	precAnd
+	precPrimary
+)
+
+// checkExpr is a Go expression translated from a CHECK constraint.
+type checkExpr struct {
+	expr string
+	prec int
+}
+
+// paren returns the expression parenthesized if its precedence is lower than prec.
+func (e checkExpr) paren(prec int) string {
+	if e.prec < prec {
+		return "(" + e.expr + ")"
+	}
+	return e.expr
+}
+
+// checkOperand is an operand of a comparison in a CHECK constraint.
+type checkOperand struct {
+	expr    string // Go expression of the value
+	kind    string // int64, float64, string or bool
+	valid   string // Go expression reporting the value is not NULL. Empty for a NOT NULL value
+	literal bool
+}
+
+// checkTranslator translates an expression of a CHECK constraint into a Go
+// expression that reports whether the constraint is satisfied.
+type checkTranslator struct {
+	short  string
+	fields []*models.Field
+}
+
+// goCheckExpr returns a Go boolean expression for a CHECK constraint of the
+// type that refers the fields via the receiver short. The expression reports
+// true when the constraint is satisfied. NULL values satisfy the constraint as
+// Spanner does. An empty string is returned if the expression cannot be
+// translated into Go.
+func (a *Generator) goCheckExpr(short string, t *models.Type, c *models.CheckConstraint) string {
+	expr, err := memefish.ParseExpr("", c.Expr)
+	if err != nil {
+		return ""
+	}
+
+	tr := &checkTranslator{short: short, fields: t.Fields}
+	e, ok := tr.translate(expr, false)
+	if !ok {
+		return ""
+	}
+
+	return e.expr
+}
+
+// checkColumns returns the names of the columns of the type referred by a
+// CHECK constraint in order of appearance.
+func (a *Generator) checkColumns(t *models.Type, c *models.CheckConstraint) []string {
+	expr, err := memefish.ParseExpr("", c.Expr)
+	if err != nil {
+		return nil
+	}
+
+	var cols []string
+	seen := make(map[string]bool)
+	ast.Inspect(expr, func(n ast.Node) bool {
+		ident, ok := n.(*ast.Ident)
+		if !ok {
+			return true
+		}
+		for _, f := range t.Fields {
+			if strings.EqualFold(f.ColumnName, ident.Name) && !seen[f.ColumnName] {
+				seen[f.ColumnName] = true
+				cols = append(cols, f.ColumnName)
+			}
+		}
+		return true
+	})
+
+	return cols
+}
+
+// translate translates expr into a Go expression. NOT is pushed down to the
+// leaves by negate so that a leaf evaluated to NULL can be simply treated as
+// satisfied.
+func (tr *checkTranslator) translate(expr ast.Expr, negate bool) (checkExpr, bool) {
+	switch e := expr.(type) {
+	case *ast.ParenExpr:
+		return tr.translate(e.Expr, negate)
+
+	case *ast.UnaryExpr:
+		if e.Op != ast.OpNot {
+			return checkExpr{}, false
+		}
+		return tr.translate(e.Expr, !negate)
+
+	case *ast.Ident:
+		v, ok := tr.operand(e)
+		if !ok || v.kind != "bool" {
+			return checkExpr{}, false
+		}
+
+		cond := v.expr
+		if negate {
+			cond = "!" + cond
+		}
+		return withValid(checkExpr{expr: cond, prec: precPrimary}, v), true
+
+	case *ast.BinaryExpr:
+		switch e.Op {
+		case ast.OpAnd, ast.OpOr:
+			l, ok := tr.translate(e.Left, negate)
+			if !ok {
+				return checkExpr{}, false
+			}
+			r, ok := tr.translate(e.Right, negate)
+			if !ok {
+				return checkExpr{}, false
+			}
+
+			if (e.Op == ast.OpAnd) != negate {
+				return checkExpr{expr: l.paren(precAnd) + " && " + r.paren(precAnd), prec: precAnd}, true
+			}
+			return checkExpr{expr: l.expr + " || " + r.expr, prec: precOr}, true
+		}
+
+		op, ok := goComparisonOp(e.Op, negate)
+		if !ok {
+			return checkExpr{}, false
+		}
+		l, ok := tr.operand(e.Left)
+		if !ok {
+			return checkExpr{}, false
+		}
+		r, ok := tr.operand(e.Right)
+		if !ok || !isComparable(l, r) {
+			return checkExpr{}, false
+		}
+		if l.kind == "bool" && op != "==" && op != "!=" {
+			return checkExpr{}, false
+		}
+
+		return withValid(checkExpr{expr: l.expr + " " + op + " " + r.expr, prec: precPrimary}, l, r), true
+
+	case *ast.InExpr:
+		values, ok := e.Right.(*ast.ValuesInCondition)
+		if !ok {
+			return checkExpr{}, false
+		}
+		l, ok := tr.operand(e.Left)
+		if !ok {
+			return checkExpr{}, false
+		}
+
+		op, sep, prec := "==", " || ", precOr
+		if e.Not != negate {
+			op, sep, prec = "!=", " && ", precAnd
+		}
+
+		var conds []string
+		for _, v := range values.Exprs {
+			r, ok := tr.operand(v)
+			if !ok || !isComparable(l, r) {
+				return checkExpr{}, false
+			}
+			conds = append(conds, l.expr+" "+op+" "+r.expr)
+		}
+		if len(conds) == 1 {
+			prec = precPrimary
+		}
+
+		return withValid(checkExpr{expr: strings.Join(conds, sep), prec: prec}, l), true
+
+	case *ast.BetweenExpr:
+		l, ok := tr.operand(e.Left)
+		if !ok {
+			return checkExpr{}, false
+		}
+		start, ok := tr.operand(e.RightStart)
+		if !ok || !isComparable(l, start) {
+			return checkExpr{}, false
+		}
+		end, ok := tr.operand(e.RightEnd)
+		if !ok || !isComparable(l, end) || l.kind == "bool" {
+			return checkExpr{}, false
+		}
+
+		if e.Not != negate {
+			return withValid(checkExpr{expr: l.expr + " < " + start.expr + " || " + l.expr + " > " + end.expr, prec: precOr}, l, start, end), true
+		}
+		return withValid(checkExpr{expr: l.expr + " >= " + start.expr + " && " + l.expr + " <= " + end.expr, prec: precAnd}, l, start, end), true
+
+	case *ast.IsNullExpr:
+		l, ok := tr.operand(e.Left)
+		if !ok || l.literal {
+			return checkExpr{}, false
+		}
+
+		isNull := e.Not == negate
+		if l.valid == "" {
+			return checkExpr{expr: strconv.FormatBool(!isNull), prec: precPrimary}, true
+		}
+		if isNull {
+			return checkExpr{expr: "!" + l.valid, prec: precPrimary}, true
+		}
+		return checkExpr{expr: l.valid, prec: precPrimary}, true
+
+	case *ast.CallExpr:
+		if len(e.Func.Idents) != 1 || !strings.EqualFold(e.Func.Idents[0].Name, "REGEXP_CONTAINS") || len(e.Args) != 2 {
+			return checkExpr{}, false
+		}
+
+		var args []ast.Expr
+		for _, arg := range e.Args {
+			a, ok := arg.(*ast.ExprArg)
+			if !ok {
+				return checkExpr{}, false
+			}
+			args = append(args, a.Expr)
+		}
+
+		l, ok := tr.operand(args[0])
+		if !ok || l.literal || l.kind != "string" {
+			return checkExpr{}, false
+		}
+		pattern, ok := args[1].(*ast.StringLiteral)
+		if !ok {
+			return checkExpr{}, false
+		}
+		if _, err := regexp.Compile(pattern.Value); err != nil {
+			return checkExpr{}, false
+		}
+
+		cond := "yoRegexp(" + strconv.Quote(pattern.Value) + ").MatchString(" + l.expr + ")"
+		if negate {
+			cond = "!" + cond
+		}
+		return withValid(checkExpr{expr: cond, prec: precPrimary}, l), true
+	}
+
+	return checkExpr{}, false
+}
+
+// operand translates expr into an operand of a comparison. It is either a
+// column of the type or a literal.
+func (tr *checkTranslator) operand(expr ast.Expr) (checkOperand, bool) {
+	switch e := expr.(type) {
+	case *ast.ParenExpr:
+		return tr.operand(e.Expr)
+
+	case *ast.Ident:
+		for _, f := range tr.fields {
+			if !strings.EqualFold(f.ColumnName, e.Name) {
+				continue
+			}
+			if f.IsHidden || f.IsGenerated || f.Type != f.OriginalType {
+				return checkOperand{}, false
+			}
+
+			v := tr.short + "." + f.Name
+			switch f.Type {
+			case "int64", "float64", "string", "bool":
+				return checkOperand{expr: v, kind: f.Type}, true
+			case "spanner.NullInt64":
+				return checkOperand{expr: v + ".Int64", kind: "int64", valid: v + ".Valid"}, true
+			case "spanner.NullFloat64":
+				return checkOperand{expr: v + ".Float64", kind: "float64", valid: v + ".Valid"}, true
+			case "spanner.NullString":
+				return checkOperand{expr: v + ".StringVal", kind: "string", valid: v + ".Valid"}, true
+			case "spanner.NullBool":
+				return checkOperand{expr: v + ".Bool", kind: "bool", valid: v + ".Valid"}, true
+			}
+			return checkOperand{}, false
+		}
+		return checkOperand{}, false
+
+	case *ast.IntLiteral:
+		return checkOperand{expr: e.Value, kind: "int64", literal: true}, true
+
+	case *ast.FloatLiteral:
+		return checkOperand{expr: e.Value, kind: "float64", literal: true}, true
+
+	case *ast.StringLiteral:
+		return checkOperand{expr: strconv.Quote(e.Value), kind: "string", literal: true}, true
+
+	case *ast.BoolLiteral:
+		return checkOperand{expr: strconv.FormatBool(e.Value), kind: "bool", literal: true}, true
+
+	case *ast.UnaryExpr:
+		if e.Op != ast.OpMinus {
+			return checkOperand{}, false
+		}
+		v, ok := tr.operand(e.Expr)
+		if !ok || !v.literal || (v.kind != "int64" && v.kind != "float64") {
+			return checkOperand{}, false
+		}
+		v.expr = "-" + v.expr
+		return v, true
+	}
+
+	return checkOperand{}, false
+}
+
+// comparable reports whether the operands can be compared in Go.
+func isComparable(l, r checkOperand) bool {
+	if l.literal && r.literal {
+		return false
+	}
+	if l.kind == r.kind {
+		return true
+	}
+
+	// an integer literal is also a float literal
+	return (l.kind == "float64" && r.literal && r.kind == "int64") ||
+		(r.kind == "float64" && l.literal && l.kind == "int64")
+}
+
+// withValid returns the expression that is also satisfied when any of the
+// nullable operands is NULL.
+func withValid(e checkExpr, operands ...checkOperand) checkExpr {
+	var conds []string
+	for _, o := range operands {
+		if o.valid != "" {
+			conds = append(conds, "!"+o.valid)
+		}
+	}
+	if len(conds) == 0 {
+		return e
+	}
+
+	conds = append(conds, e.paren(precPrimary))
+	return checkExpr{expr: strings.Join(conds, " || "), prec: precOr}
+}
+
+// goComparisonOp returns the Go comparison operator of op. The operator is
+// inverted if negate is true.
+func goComparisonOp(op ast.BinaryOp, negate bool) (string, bool) {
+	ops := map[ast.BinaryOp][2]string{
+		ast.OpEqual:        {"==", "!="},
+		ast.OpNotEqual:     {"!=", "=="},
+		ast.OpLess:         {"<", ">="},
+		ast.OpLessEqual:    {"<=", ">"},
+		ast.OpGreater:      {">", "<="},
+		ast.OpGreaterEqual: {">=", "<"},
+	}
+
+	v, ok := ops[op]
+	if !ok {
+		return "", false
+	}
+	if negate {
+		return v[1], true
+	}
+	return v[0], true
+}
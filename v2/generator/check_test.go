@@ -0,0 +1,92 @@
+// Copyright (c) 2020 Mercari, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package generator
+
+import (
+	"testing"
+
+	"go.mercari.io/yo/v2/models"
+)
+
+func TestGoCheckExpr(t *testing.T) {
+	typ := &models.Type{
+		Name: "Item",
+		Fields: []*models.Field{
+			{Name: "ID", Type: "int64", OriginalType: "int64", ColumnName: "ID"},
+			{Name: "Price", Type: "int64", OriginalType: "int64", ColumnName: "Price"},
+			{Name: "Discount", Type: "spanner.NullInt64", OriginalType: "spanner.NullInt64", ColumnName: "Discount"},
+			{Name: "Rate", Type: "float64", OriginalType: "float64", ColumnName: "Rate"},
+			{Name: "Status", Type: "string", OriginalType: "string", ColumnName: "Status"},
+			{Name: "Code", Type: "spanner.NullString", OriginalType: "spanner.NullString", ColumnName: "Code"},
+			{Name: "Active", Type: "bool", OriginalType: "bool", ColumnName: "Active"},
+			{Name: "Count", Type: "uint32", OriginalType: "int64", ColumnName: "Count"},
+			{Name: "Total", Type: "int64", OriginalType: "int64", ColumnName: "Total", IsGenerated: true},
+			{Name: "CreatedAt", Type: "time.Time", OriginalType: "time.Time", ColumnName: "CreatedAt"},
+		},
+	}
+
+	table := []struct {
+		expr     string
+		expected string
+	}{
+		{expr: "Price > 0", expected: "i.Price > 0"},
+		{expr: "price >= -1.5", expected: ""},
+		{expr: "Rate >= -1.5", expected: "i.Rate >= -1.5"},
+		{expr: "Rate < 1", expected: "i.Rate < 1"},
+		{expr: "Price <> ID", expected: "i.Price != i.ID"},
+		{expr: "Price = Rate", expected: ""},
+		{expr: "Discount < Price", expected: "!i.Discount.Valid || i.Discount.Int64 < i.Price"},
+		{expr: "Price > 0 AND (Status = 'A' OR Active)", expected: `i.Price > 0 && (i.Status == "A" || i.Active)`},
+		{expr: "NOT Active", expected: "!i.Active"},
+		{expr: "Status", expected: ""},
+		{expr: "Price > 0 AND (Status = 'A' OR Active = TRUE)", expected: `i.Price > 0 && (i.Status == "A" || i.Active == true)`},
+		{expr: "NOT (Price > 0 AND Rate > 0)", expected: "i.Price <= 0 || i.Rate <= 0"},
+		{expr: "NOT (Discount > 0 OR Price > 0)", expected: "(!i.Discount.Valid || i.Discount.Int64 <= 0) && i.Price <= 0"},
+		{expr: "Status IN ('A', 'B')", expected: `i.Status == "A" || i.Status == "B"`},
+		{expr: "Status NOT IN ('A', 'B')", expected: `i.Status != "A" && i.Status != "B"`},
+		{expr: "Discount IN (1)", expected: "!i.Discount.Valid || i.Discount.Int64 == 1"},
+		{expr: "Discount IN (1, 2)", expected: "!i.Discount.Valid || (i.Discount.Int64 == 1 || i.Discount.Int64 == 2)"},
+		{expr: "Status IN (1)", expected: ""},
+		{expr: "Discount BETWEEN 0 AND Price", expected: "!i.Discount.Valid || (i.Discount.Int64 >= 0 && i.Discount.Int64 <= i.Price)"},
+		{expr: "Price NOT BETWEEN 1 AND 10", expected: "i.Price < 1 || i.Price > 10"},
+		{expr: "Code IS NULL OR Price > 0", expected: "!i.Code.Valid || i.Price > 0"},
+		{expr: "Code IS NOT NULL", expected: "i.Code.Valid"},
+		{expr: "Status IS NOT NULL", expected: "true"},
+		{expr: "REGEXP_CONTAINS(Code, r'^[A-Z]+$')", expected: `!i.Code.Valid || yoRegexp("^[A-Z]+$").MatchString(i.Code.StringVal)`},
+		{expr: "NOT REGEXP_CONTAINS(Status, '\\\\d')", expected: `!yoRegexp("\\d").MatchString(i.Status)`},
+		{expr: "REGEXP_CONTAINS(Status, '(')", expected: ""},
+		{expr: "Count > 0", expected: ""},
+		{expr: "Total > 0", expected: ""},
+		{expr: "CreatedAt > '2020-01-01'", expected: ""},
+		{expr: "Unknown > 0", expected: ""},
+		{expr: "Price + 1 > 0", expected: ""},
+		{expr: "1 > 0", expected: ""},
+	}
+
+	g := newTestGenerator(t, &fakeLoader{}, nil, nil)
+	for _, tc := range table {
+		t.Run(tc.expr, func(t *testing.T) {
+			got := g.goCheckExpr("i", typ, &models.CheckConstraint{Expr: tc.expr})
+			if got != tc.expected {
+				t.Errorf("expect %q, but got %q", tc.expected, got)
+			}
+		})
+	}
+}
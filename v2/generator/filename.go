@@ -0,0 +1,112 @@
+// Copyright (c) 2020 Mercari, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package generator
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+
+	"go.mercari.io/yo/v2/internal"
+	"go.mercari.io/yo/v2/models"
+)
+
+// Names of the builtin filename strategies.
+const (
+	FilenameStrategySnake = "snake"
+	FilenameStrategyLower = "lower"
+	FilenameStrategyTable = "table"
+)
+
+// FilenameStrategy returns the file name without the suffix for the name of
+// a type or a global module. typ is the type of a type module, and nil for a
+// global module.
+type FilenameStrategy func(name string, typ *models.Type) (string, error)
+
+// FilenameData is the data of the template of a filename strategy.
+type FilenameData struct {
+	Name string       // name of the type or the global module
+	Type *models.Type // type of a type module. nil for a global module
+}
+
+// NewFilenameStrategy returns the filename strategy of s. s is one of snake,
+// lower and table, or a template executed with FilenameData such as
+// {{ toLower .Name }}. It returns the snake strategy if s is empty.
+func NewFilenameStrategy(s string) (FilenameStrategy, error) {
+	switch s {
+	case "", FilenameStrategySnake:
+		return snakeFilename, nil
+	case FilenameStrategyLower:
+		return lowerFilename, nil
+	case FilenameStrategyTable:
+		return tableFilename, nil
+	}
+
+	if !strings.Contains(s, "{{") {
+		return nil, fmt.Errorf("unknown filename strategy %s: it must be %s, %s, %s or a template",
+			s, FilenameStrategySnake, FilenameStrategyLower, FilenameStrategyTable)
+	}
+
+	tmpl, err := template.New("filename").Funcs(template.FuncMap{
+		"snake":   internal.CamelToScake,
+		"toLower": strings.ToLower,
+	}).Option("missingkey=error").Parse(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid filename strategy: %v", err)
+	}
+
+	return func(name string, typ *models.Type) (string, error) {
+		var sb strings.Builder
+		if err := tmpl.Execute(&sb, &FilenameData{Name: name, Type: typ}); err != nil {
+			return "", fmt.Errorf("failed to execute the filename strategy for %s: %v", name, err)
+		}
+
+		filename := strings.TrimSpace(sb.String())
+		if filename == "" {
+			return "", fmt.Errorf("the filename strategy returns an empty name for %s", name)
+		}
+		if strings.ContainsAny(filename, `/\`) {
+			return "", fmt.Errorf("the filename strategy returns a path for %s: %s", name, filename)
+		}
+		return filename, nil
+	}, nil
+}
+
+// snakeFilename converts the name to snake_case, such as singer_album for
+// SingerAlbum.
+func snakeFilename(name string, typ *models.Type) (string, error) {
+	return internal.CamelToScake(name), nil
+}
+
+// lowerFilename converts the name to lower case without inserting
+// underscores, such as singeralbum for SingerAlbum. The underscores in the
+// name such as yo_db are kept as they are.
+func lowerFilename(name string, typ *models.Type) (string, error) {
+	return strings.ToLower(name), nil
+}
+
+// tableFilename uses the table name of a type as it is. The name of a global
+// module is converted to snake_case.
+func tableFilename(name string, typ *models.Type) (string, error) {
+	if typ == nil {
+		return internal.CamelToScake(name), nil
+	}
+	return strings.ReplaceAll(typ.TableName, ".", "_"), nil
+}
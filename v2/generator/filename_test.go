@@ -0,0 +1,99 @@
+// Copyright (c) 2020 Mercari, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package generator
+
+import (
+	"testing"
+
+	"go.mercari.io/yo/v2/models"
+)
+
+func TestNewFilenameStrategy(t *testing.T) {
+	typ := &models.Type{Name: "SingerAlbum", TableName: "singer_albums"}
+
+	table := []struct {
+		strategy string
+		name     string
+		typ      *models.Type
+		expected string
+	}{
+		{strategy: "", name: "SingerAlbum", typ: typ, expected: "singer_album"},
+		{strategy: "snake", name: "SingerAlbum", typ: typ, expected: "singer_album"},
+		{strategy: "lower", name: "SingerAlbum", typ: typ, expected: "singeralbum"},
+		{strategy: "lower", name: "yo_db", expected: "yo_db"},
+		{strategy: "table", name: "SingerAlbum", typ: typ, expected: "singer_albums"},
+		{strategy: "table", name: "SingerAlbum", typ: &models.Type{TableName: "music.SingerAlbums"}, expected: "music_SingerAlbums"},
+		{strategy: "table", name: "yo_db", expected: "yo_db"},
+		{strategy: "{{ toLower .Name }}_gen", name: "SingerAlbum", typ: typ, expected: "singeralbum_gen"},
+		{strategy: "{{ if .Type }}{{ .Type.TableName }}{{ else }}{{ snake .Name }}{{ end }}", name: "yo_db", expected: "yo_db"},
+	}
+
+	for _, tc := range table {
+		t.Run(tc.strategy+"/"+tc.name, func(t *testing.T) {
+			s, err := NewFilenameStrategy(tc.strategy)
+			if err != nil {
+				t.Fatalf("failed to create the filename strategy: %v", err)
+			}
+
+			got, err := s(tc.name, tc.typ)
+			if err != nil {
+				t.Fatalf("failed to get the filename: %v", err)
+			}
+			if got != tc.expected {
+				t.Errorf("expected %q, but got %q", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestNewFilenameStrategy_Errors(t *testing.T) {
+	table := map[string]struct {
+		strategy string
+		expected string
+	}{
+		"Unknown": {
+			strategy: "camel",
+			expected: "unknown filename strategy camel: it must be snake, lower, table or a template",
+		},
+		"Empty": {
+			strategy: "{{ if false }}x{{ end }}",
+			expected: "the filename strategy returns an empty name for Singer",
+		},
+		"Path": {
+			strategy: "dir/{{ .Name }}",
+			expected: "the filename strategy returns a path for Singer: dir/Singer",
+		},
+	}
+
+	for name, tc := range table {
+		t.Run(name, func(t *testing.T) {
+			s, err := NewFilenameStrategy(tc.strategy)
+			if err == nil {
+				_, err = s("Singer", &models.Type{Name: "Singer", TableName: "Singers"})
+			}
+			if err == nil {
+				t.Fatal("expected an error, but got nil")
+			}
+			if err.Error() != tc.expected {
+				t.Errorf("expected error %q, but got %q", tc.expected, err.Error())
+			}
+		})
+	}
+}
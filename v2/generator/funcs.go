@@ -21,6 +21,7 @@ package generator
 
 import (
 	"fmt"
+	"path"
 	"strconv"
 	"strings"
 	"text/template"
@@ -46,15 +47,32 @@ func (a *Generator) newTemplateFuncs() template.FuncMap {
 
 		"hasField":   a.hasField,
 		"fieldNames": a.fieldNames,
+		"structTag":  a.structTag,
 
 		"goParam":         a.goParam,
 		"goEncodedParam":  a.goEncodedParam,
 		"goParams":        a.goParams,
 		"goEncodedParams": a.goEncodedParams,
 
-		"escape":    a.escape,
-		"toLower":   a.toLower,
-		"pluralize": a.pluralize,
+		"escape":       a.escape,
+		"escapeTable":  a.escapeTable,
+		"nthParam":     a.nthParam,
+		"nthParamName": a.nthParamName,
+		"forceIndex":   a.forceIndex,
+		"indexOrderBy": a.indexOrderBy,
+		"isPostgreSQL": a.isPostgreSQL,
+		"toLower":      a.toLower,
+		"hasPrefix":    a.hasPrefix,
+		"pluralize":    a.pluralize,
+
+		"goCheckExpr":       a.goCheckExpr,
+		"checkColumns":      a.checkColumns,
+		"validateMutations": a.shouldValidateMutations,
+
+		"protoImports":         a.protoImports,
+		"nullableProto":        a.nullableProto,
+		"hasNullableProto":     a.hasNullableProto,
+		"hasRowDeletionPolicy": a.hasRowDeletionPolicy,
 	}
 }
 
@@ -103,7 +121,7 @@ func (a *Generator) columnNames(fields []*models.Field) string {
 		if i != 0 {
 			str = str + ", "
 		}
-		str = str + internal.EscapeColumnName(f.ColumnName)
+		str = str + a.escape(f.ColumnName)
 		i++
 	}
 	return str
@@ -125,7 +143,7 @@ func (a *Generator) columnNamesWithoutHidden(fields []*models.Field) string {
 		if i != 0 {
 			str = str + ", "
 		}
-		str = str + internal.EscapeColumnName(f.ColumnName)
+		str = str + a.escape(f.ColumnName)
 		i++
 	}
 	return str
@@ -144,7 +162,7 @@ func (a *Generator) columnNamesQuery(fields []*models.Field, sep string) string
 		if i != 0 {
 			str = str + sep
 		}
-		str = str + internal.EscapeColumnName(f.ColumnName) + " = " + a.loader.NthParam(i)
+		str = str + a.escape(f.ColumnName) + " = " + a.loader.NthParam(i)
 		i++
 	}
 
@@ -171,7 +189,10 @@ func (a *Generator) shortName(typ string, scopeConflicts ...interface{}) string
 	var ok bool
 
 	// check short name map
-	if v, ok = ShortNameTypeMap[typ]; !ok {
+	shortNameTypeMapMu.RLock()
+	v, ok = ShortNameTypeMap[typ]
+	shortNameTypeMapMu.RUnlock()
+	if !ok {
 		// calc the short name
 		u := []string{}
 		for _, s := range strings.Split(strings.ToLower(snaker.CamelToSnake(typ)), "_") {
@@ -187,7 +208,9 @@ func (a *Generator) shortName(typ string, scopeConflicts ...interface{}) string
 		}
 
 		// store back to short name map
+		shortNameTypeMapMu.Lock()
 		ShortNameTypeMap[typ] = v
+		shortNameTypeMapMu.Unlock()
 	}
 
 	// add scopeConflicts to conflicts
@@ -230,7 +253,7 @@ func (a *Generator) columnPrefixNames(fields []*models.Field, prefix string) str
 		if i != 0 {
 			str = str + ", "
 		}
-		str = str + prefix + "." + internal.EscapeColumnName(f.ColumnName)
+		str = str + prefix + "." + a.escape(f.ColumnName)
 		i++
 	}
 
@@ -434,14 +457,147 @@ func (a *Generator) nullcheck(field *models.Field) string {
 
 // escaped returns the ColumnName of col. It is escaped for query.
 func (a *Generator) escape(col string) string {
+	if a.dialect == models.DialectPostgreSQL {
+		return internal.EscapePGIdentifier(col)
+	}
 	return internal.EscapeColumnName(col)
 }
 
+// escapeTable returns the table name for query. It is escaped for query.
+func (a *Generator) escapeTable(table string) string {
+	if a.dialect == models.DialectPostgreSQL {
+		return internal.EscapePGTableName(table)
+	}
+	return internal.EscapeTableName(table)
+}
+
+// nthParam returns the 0-based Nth param in query.
+func (a *Generator) nthParam(i int) string {
+	return a.loader.NthParam(i)
+}
+
+// nthParamName returns the name of the 0-based Nth param used as a key of
+// spanner.Statement.Params.
+func (a *Generator) nthParamName(i int) string {
+	return a.loader.NthParamName(i)
+}
+
+// forceIndex returns the table hint to force the index for query.
+func (a *Generator) forceIndex(index string) string {
+	if a.dialect == models.DialectPostgreSQL {
+		return " /*@ FORCE_INDEX=" + internal.EscapePGIdentifier(index) + " */"
+	}
+	return "@{FORCE_INDEX=" + index + "}"
+}
+
+// indexOrderBy returns the ORDER BY list of the index keys in the sort
+// direction of the index.
+func (a *Generator) indexOrderBy(keys []*models.IndexKey) string {
+	cols := make([]string, 0, len(keys))
+	for _, k := range keys {
+		col := a.escape(k.Field.ColumnName)
+		if k.Desc {
+			col += " DESC"
+		}
+		cols = append(cols, col)
+	}
+
+	return strings.Join(cols, ", ")
+}
+
+// structTag returns the struct tag of the tags such as
+// `spanner:"Id" json:"id"` without the backquotes.
+func (a *Generator) structTag(tags []*models.Tag) string {
+	s := make([]string, 0, len(tags))
+	for _, t := range tags {
+		s = append(s, t.Key+":"+strconv.Quote(t.Value))
+	}
+
+	return strings.Join(s, " ")
+}
+
+// isPostgreSQL reports whether the database is in the PostgreSQL dialect.
+func (a *Generator) isPostgreSQL() bool {
+	return a.dialect == models.DialectPostgreSQL
+}
+
 // toLower converts s to lower case.
 func (a *Generator) toLower(s string) string {
 	return strings.ToLower(s)
 }
 
+// hasPrefix reports whether s begins with prefix.
+func (a *Generator) hasPrefix(s, prefix string) bool {
+	return strings.HasPrefix(s, prefix)
+}
+
+// shouldValidateMutations reports whether mutation methods validate the
+// values before building a mutation.
+func (a *Generator) shouldValidateMutations() bool {
+	return a.validateMutations
+}
+
+// protoImports returns the import specs of the proto types and the other Go
+// types with import paths used by fields keyed by the package names. The
+// package name is given as the alias if it differs from the last element of
+// the import path.
+func (a *Generator) protoImports(fields []*models.Field) (map[string]string, error) {
+	paths := map[string]string{}
+	imports := map[string]string{}
+	for _, f := range fields {
+		if f.ImportPath == "" {
+			continue
+		}
+
+		typ := strings.TrimLeft(f.OriginalType, "[]*")
+		pkg := typ[:strings.LastIndex(typ, ".")]
+		if p, ok := paths[pkg]; ok && p != f.ImportPath {
+			return nil, fmt.Errorf("package name %s is used by both of %s and %s", pkg, p, f.ImportPath)
+		}
+		paths[pkg] = f.ImportPath
+
+		spec := strconv.Quote(f.ImportPath)
+		if path.Base(f.ImportPath) != pkg {
+			spec = pkg + " " + spec
+		}
+		imports[pkg] = spec
+	}
+
+	return imports, nil
+}
+
+// nullableProto reports whether the field is a nullable PROTO or ENUM column
+// that is not an array. The Spanner client cannot decode NULL into it.
+func (a *Generator) nullableProto(f *models.Field) bool {
+	return f.ProtoKind != "" && !f.IsNotNull && !strings.HasPrefix(f.OriginalType, "[]")
+}
+
+// hasNullableProto reports whether any of the types has a nullable PROTO or
+// ENUM field.
+func (a *Generator) hasNullableProto(schema *models.Schema) bool {
+	for _, t := range schema.Types {
+		for _, f := range t.Fields {
+			if !f.IsHidden && a.nullableProto(f) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// hasRowDeletionPolicy reports whether any of the types has a row deletion
+// policy.
+func (a *Generator) hasRowDeletionPolicy(schema *models.Schema) bool {
+	for _, t := range schema.Types {
+		if t.RowDeletionPolicy != nil {
+			return true
+		}
+	}
+
+	return false
+}
+
 // pluralize converts s to plural.
 func (a *Generator) pluralize(s string) string {
 	return a.inflector.Pluralize(s)
@@ -21,13 +21,21 @@ package generator
 
 import (
 	"bytes"
+	"context"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
 
 	"go.mercari.io/yo/v2/internal"
 	"go.mercari.io/yo/v2/models"
 	"go.mercari.io/yo/v2/module"
+	"go.mercari.io/yo/v2/plugin"
 )
 
 // Loader is the common interface for database drivers that can generate code
@@ -35,8 +43,21 @@ import (
 type Loader interface {
 	// NthParam returns the 0-based Nth param for the Loader.
 	NthParam(i int) string
+
+	// NthParamName returns the name of the 0-based Nth param used as a key
+	// of spanner.Statement.Params.
+	NthParamName(i int) string
 }
 
+// generatedMarker is the comment in the header of the files generated by yo.
+// The files with it are removed by the prune mode when they are not
+// generated anymore.
+const generatedMarker = "// Code generated by yo. DO NOT EDIT."
+
+// ErrNotUpToDate is returned by Generate in the check mode if the files on
+// disk differ from the generated code.
+var ErrNotUpToDate = errors.New("generated code is not up to date")
+
 type GeneratorOption struct {
 	PackageName    string
 	Tags           string
@@ -44,13 +65,52 @@ type GeneratorOption struct {
 	BaseDir        string
 	DisableFormat  bool
 
+	// ValidateMutations makes mutation methods validate the values by Validate
+	// before building a mutation.
+	ValidateMutations bool
+
+	// SingleFile writes the code of all types and global modules into the
+	// file Filename under BaseDir.
+	SingleFile bool
+	Filename   string
+
+	// FilenameStrategy decides the file names of the types and global
+	// modules. It defaults to the snake strategy.
+	FilenameStrategy FilenameStrategy
+
+	// Check compares the generated code with the files under BaseDir
+	// instead of writing it. The diffs and the files to be created or
+	// removed are written to CheckOutput, which defaults to os.Stdout.
+	Check       bool
+	CheckOutput io.Writer
+
+	// Prune removes the files generated by yo under BaseDir which are not
+	// generated anymore, such as the files of dropped tables.
+	Prune bool
+
+	// Plugins are the paths of the external generator plugins.
+	// PluginParameters are passed to all of them. See the plugin package for
+	// the protocol.
+	Plugins          []string
+	PluginParameters []string
+
 	HeaderModule  module.Module
 	GlobalModules []module.Module
 	TypeModules   []module.Module
 }
 
 func NewGenerator(loader Loader, inflector internal.Inflector, opt GeneratorOption) *Generator {
-	return &Generator{
+	filenameStrategy := opt.FilenameStrategy
+	if filenameStrategy == nil {
+		filenameStrategy = snakeFilename
+	}
+
+	checkOutput := opt.CheckOutput
+	if checkOutput == nil {
+		checkOutput = os.Stdout
+	}
+
+	g := &Generator{
 		loader:         loader,
 		inflector:      inflector,
 		packageName:    opt.PackageName,
@@ -59,6 +119,16 @@ func NewGenerator(loader Loader, inflector internal.Inflector, opt GeneratorOpti
 		baseDir:        opt.BaseDir,
 		disableFormat:  opt.DisableFormat,
 
+		validateMutations: opt.ValidateMutations,
+		singleFile:        opt.SingleFile,
+		filename:          opt.Filename,
+		filenameStrategy:  filenameStrategy,
+		check:             opt.Check,
+		checkOutput:       checkOutput,
+		prune:             opt.Prune,
+		plugins:           opt.Plugins,
+		pluginParameters:  opt.PluginParameters,
+
 		headerModule:  opt.HeaderModule,
 		globalModules: opt.GlobalModules,
 		typeModules:   opt.TypeModules,
@@ -66,6 +136,9 @@ func NewGenerator(loader Loader, inflector internal.Inflector, opt GeneratorOpti
 		files:              make(map[string]*FileBuffer),
 		nameConflictSuffix: "z",
 	}
+	g.templates = g.newTemplateSet()
+
+	return g
 }
 
 type Generator struct {
@@ -79,11 +152,21 @@ type Generator struct {
 	baseDir           string
 	tempDir           string
 	disableFormat     bool
+	validateMutations bool
+	singleFile        bool
+	filenameStrategy  FilenameStrategy
+	check             bool
+	checkOutput       io.Writer
+	prune             bool
+	plugins           []string
+	pluginParameters  []string
+	dialect           string
 
 	headerModule  module.Module
 	globalModules []module.Module
 	typeModules   []module.Module
 
+	templates          *templateSet
 	files              map[string]*FileBuffer
 	nameConflictSuffix string
 }
@@ -94,7 +177,8 @@ func (g *Generator) newTemplateSet() *templateSet {
 	}
 }
 
-func (g *Generator) Generate(schema *models.Schema) error {
+// Generate generates the code of the schema. ctx is used to run the plugins.
+func (g *Generator) Generate(ctx context.Context, schema *models.Schema) error {
 	tempDir, err := os.MkdirTemp("", "yo_")
 	if err != nil {
 		return fmt.Errorf("failed to create temp dir: %v", err)
@@ -104,12 +188,32 @@ func (g *Generator) Generate(schema *models.Schema) error {
 	g.tempDir = tempDir
 	defer os.RemoveAll(g.tempDir)
 
-	// execute type modules
+	g.dialect = schema.Dialect
+
+	// execute type modules for the tables concurrently. The chunks are added
+	// in the order of the modules and the tables for the deterministic output
+	type typeJob struct {
+		mod module.Module
+		tbl *models.Type
+	}
+	var jobs []typeJob
 	for _, mod := range g.typeModules {
 		for _, tbl := range schema.Types {
-			if err := g.ExecuteTemplate(mod, tbl.Name, tbl); err != nil {
-				return err
-			}
+			jobs = append(jobs, typeJob{mod: mod, tbl: tbl})
+		}
+	}
+
+	tbufs := make([]*TBuf, len(jobs))
+	if err := parallel(len(jobs), func(i int) error {
+		tbuf, err := g.executeTemplate(jobs[i].mod, jobs[i].tbl.Name, jobs[i].tbl)
+		tbufs[i] = tbuf
+		return err
+	}); err != nil {
+		return err
+	}
+	for i, tbuf := range tbufs {
+		if err := g.addChunk(tbuf, jobs[i].tbl); err != nil {
+			return err
 		}
 	}
 
@@ -126,6 +230,11 @@ func (g *Generator) Generate(schema *models.Schema) error {
 		}
 	}
 
+	// execute plugins
+	if err := g.executePlugins(ctx, schema); err != nil {
+		return err
+	}
+
 	if err := g.writeFiles(ds); err != nil {
 		return err
 	}
@@ -133,28 +242,99 @@ func (g *Generator) Generate(schema *models.Schema) error {
 	return nil
 }
 
-func (g *Generator) getFile(name string) *FileBuffer {
-	var filename = internal.CamelToScake(name) + g.filenameSuffix
+// getFile returns the file to write the code of the type or the global module
+// of the name. typ is the type of a type module, and nil for a global module.
+func (g *Generator) getFile(name string, typ *models.Type) (*FileBuffer, error) {
+	var filename string
+	if g.singleFile {
+		filename = g.filename
+	} else {
+		base, err := g.filenameStrategy(name, typ)
+		if err != nil {
+			return nil, err
+		}
+		filename = base + g.filenameSuffix
+	}
 	filename = path.Join(g.baseDir, filename)
 
 	f, ok := g.files[filename]
 	if ok {
-		return f
+		if !g.singleFile && f.BaseName != name {
+			return nil, fmt.Errorf("file name %s is used by both of %s and %s: change the filename strategy", path.Base(filename), f.BaseName, name)
+		}
+		return f, nil
 	}
 
 	file := &FileBuffer{
-		FileName: filename,
-		BaseName: name,
-		TempDir:  g.tempDir,
+		FileName:     filename,
+		BaseName:     name,
+		TempDir:      g.tempDir,
+		MergeImports: g.singleFile,
+	}
+	if g.singleFile {
+		file.BaseName = g.packageName
 	}
 
 	g.files[filename] = file
-	return file
+	return file, nil
 }
 
-// writeFiles writes the generated definitions.
+// executePlugins runs the plugins with the schema, and adds the files of
+// them. The imports of a file are merged into the header.
+func (g *Generator) executePlugins(ctx context.Context, schema *models.Schema) error {
+	if len(g.plugins) == 0 {
+		return nil
+	}
+
+	req := &plugin.Request{
+		Version: plugin.Version,
+		Schema:  plugin.NewSchema(schema),
+		Options: &plugin.Options{
+			PackageName:    g.packageName,
+			BuildTag:       g.tags,
+			FilenameSuffix: g.filenameSuffix,
+			Parameters:     g.pluginParameters,
+		},
+	}
+
+	for _, p := range g.plugins {
+		resp, err := plugin.Run(ctx, p, req, os.Stderr)
+		if err != nil {
+			return err
+		}
+
+		for _, f := range resp.Files {
+			filename := path.Join(g.baseDir, f.Filename)
+			if _, ok := g.files[filename]; ok {
+				return fmt.Errorf("file %s of plugin %s is already generated", f.Filename, p)
+			}
+
+			g.files[filename] = &FileBuffer{
+				FileName: filename,
+				BaseName: strings.TrimSuffix(f.Filename, ".go"),
+				Chunks: []*TBuf{
+					{Name: f.Filename, Buf: bytes.NewBufferString(f.Content)},
+				},
+				TempDir:      g.tempDir,
+				MergeImports: true,
+			}
+		}
+	}
+
+	return nil
+}
+
+// writeFiles writes the generated definitions. The files are formatted
+// concurrently.
 func (g *Generator) writeFiles(ds *basicDataSet) error {
-	for _, file := range g.files {
+	filenames := make([]string, 0, len(g.files))
+	for filename := range g.files {
+		filenames = append(filenames, filename)
+	}
+	sort.Strings(filenames)
+
+	if err := parallel(len(filenames), func(i int) error {
+		file := g.files[filenames[i]]
 		if err := g.ExecuteHeaderTemplate(g.headerModule, file, ds); err != nil {
 			return err
 		}
@@ -162,48 +342,222 @@ func (g *Generator) writeFiles(ds *basicDataSet) error {
 		if err := file.WriteTempFile(); err != nil {
 			return err
 		}
+
+		return file.Postprocess(g.disableFormat)
+	}); err != nil {
+		return err
+	}
+
+	if g.check {
+		return g.checkFiles()
 	}
 
 	for _, file := range g.files {
-		if err := file.Postprocess(g.disableFormat); err != nil {
+		if err := file.Finalize(); err != nil {
 			return err
 		}
 	}
 
-	for _, file := range g.files {
-		if err := file.Finalize(); err != nil {
+	if g.prune {
+		staleFiles, err := g.staleFiles()
+		if err != nil {
 			return err
 		}
+		for _, filename := range staleFiles {
+			if err := os.Remove(filename); err != nil {
+				return fmt.Errorf("failed to remove stale file %s: %v", filename, err)
+			}
+		}
 	}
 
 	return nil
 }
 
+// staleFiles returns the Go files generated by yo under the base directory,
+// which are not generated anymore. A file is generated by yo if it has
+// generatedMarker before the package clause, so hand-written files are never
+// stale.
+func (g *Generator) staleFiles() ([]string, error) {
+	entries, err := os.ReadDir(g.baseDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read dir %s: %v", g.baseDir, err)
+	}
+
+	var files []string
+	for _, e := range entries {
+		filename := path.Join(g.baseDir, e.Name())
+		if e.IsDir() || path.Ext(filename) != ".go" {
+			continue
+		}
+		if _, ok := g.files[filename]; ok {
+			continue
+		}
+
+		generated, err := isGeneratedFile(filename)
+		if err != nil {
+			return nil, err
+		}
+		if generated {
+			files = append(files, filename)
+		}
+	}
+
+	return files, nil
+}
+
+// isGeneratedFile reports whether the Go file has generatedMarker before the
+// package clause.
+func isGeneratedFile(filename string) (bool, error) {
+	b, err := os.ReadFile(filename)
+	if err != nil {
+		return false, fmt.Errorf("failed to read file %s: %v", filename, err)
+	}
+
+	for _, line := range strings.Split(string(b), "\n") {
+		line = strings.TrimSpace(line)
+		if line == generatedMarker {
+			return true, nil
+		}
+		if strings.HasPrefix(line, "package ") {
+			break
+		}
+	}
+
+	return false, nil
+}
+
+// checkFiles compares the generated files with the files on disk. It also
+// reports the stale files with the suffix under the base directory, which are
+// not generated anymore.
+func (g *Generator) checkFiles() error {
+	filenames := make([]string, 0, len(g.files))
+	for filename := range g.files {
+		filenames = append(filenames, filename)
+	}
+	sort.Strings(filenames)
+
+	var problems []string
+	for _, filename := range filenames {
+		_, err := os.Stat(filename)
+		exists := err == nil
+
+		ok, err := g.files[filename].Check(g.checkOutput)
+		if err != nil {
+			return err
+		}
+		switch {
+		case ok:
+		case exists:
+			problems = append(problems, fmt.Sprintf("%s is out of date", filename))
+		default:
+			problems = append(problems, fmt.Sprintf("%s would be created", filename))
+		}
+	}
+
+	staleFiles, err := g.staleFiles()
+	if err != nil {
+		return err
+	}
+	for _, filename := range staleFiles {
+		problems = append(problems, fmt.Sprintf("%s is stale", filename))
+	}
+
+	if len(problems) == 0 {
+		return nil
+	}
+
+	for _, p := range problems {
+		if _, err := fmt.Fprintln(g.checkOutput, p); err != nil {
+			return err
+		}
+	}
+
+	return fmt.Errorf("%w: run generate without the check mode to update the files", ErrNotUpToDate)
+}
+
 // ExecuteTemplate loads and parses the supplied template with name and
 // executes it with obj as the context.
+//
+// A file is not generated if the template generates nothing.
 func (g *Generator) ExecuteTemplate(mod module.Module, name string, obj interface{}) error {
-	file := g.getFile(name)
-	tbuf := TBuf{
+	tbuf, err := g.executeTemplate(mod, name, obj)
+	if err != nil {
+		return err
+	}
+
+	return g.addChunk(tbuf, obj)
+}
+
+// executeTemplate executes the template of the module with obj. It returns
+// nil if the template generates nothing. It is safe for concurrent use.
+func (g *Generator) executeTemplate(mod module.Module, name string, obj interface{}) (*TBuf, error) {
+	tbuf := &TBuf{
 		Name: name,
 		Buf:  new(bytes.Buffer),
 	}
 
 	// execute template
-	if err := g.newTemplateSet().Execute(tbuf.Buf, mod, obj); err != nil {
-		return fmt.Errorf("error happened while executing template: %v", err)
+	if err := g.templates.Execute(tbuf.Buf, mod, obj); err != nil {
+		return nil, fmt.Errorf("error happened while executing template: %v", err)
+	}
+
+	if len(bytes.TrimSpace(tbuf.Buf.Bytes())) == 0 {
+		return nil, nil
 	}
 
-	file.Chunks = append(file.Chunks, &tbuf)
+	return tbuf, nil
+}
+
+// addChunk adds the executed template to the file of the name of tbuf. It
+// does nothing if tbuf is nil.
+func (g *Generator) addChunk(tbuf *TBuf, obj interface{}) error {
+	if tbuf == nil {
+		return nil
+	}
+
+	typ, _ := obj.(*models.Type)
+	file, err := g.getFile(tbuf.Name, typ)
+	if err != nil {
+		return err
+	}
+	file.Chunks = append(file.Chunks, tbuf)
 	return nil
 }
 
 func (g *Generator) ExecuteHeaderTemplate(mod module.Module, file *FileBuffer, obj interface{}) error {
 	buf := new(bytes.Buffer)
 
-	if err := g.newTemplateSet().Execute(buf, mod, obj); err != nil {
+	if err := g.templates.Execute(buf, mod, obj); err != nil {
 		return err
 	}
 
 	file.Header = buf.Bytes()
 	return nil
 }
+
+// parallel calls fn with 0 to n-1 concurrently by up to GOMAXPROCS
+// goroutines. It returns the error of the smallest index, so the error does
+// not depend on the scheduling.
+func parallel(n int, fn func(i int) error) error {
+	errs := make([]error, n)
+	sem := make(chan struct{}, runtime.GOMAXPROCS(0))
+
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			errs[i] = fn(i)
+		}()
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
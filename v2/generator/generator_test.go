@@ -20,22 +20,43 @@
 package generator
 
 import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"go.mercari.io/yo/v2/internal"
 	"go.mercari.io/yo/v2/models"
+	"go.mercari.io/yo/v2/module"
+	"go.mercari.io/yo/v2/module/builtin"
+	"go.mercari.io/yo/v2/plugin"
 )
 
-type fakeLoader struct{}
+type fakeLoader struct {
+	dialect string
+}
+
+func (l *fakeLoader) NthParam(i int) string {
+	if l.dialect == models.DialectPostgreSQL {
+		return fmt.Sprintf("$%d", i+1)
+	}
+	return fmt.Sprintf("@param%d", i)
+}
 
-func (*fakeLoader) NthParam(int) string {
-	return "@"
+func (l *fakeLoader) NthParamName(i int) string {
+	if l.dialect == models.DialectPostgreSQL {
+		return fmt.Sprintf("p%d", i+1)
+	}
+	return fmt.Sprintf("param%d", i)
 }
 
-func newTestGenerator(t *testing.T) *Generator {
+func newTestGenerator(t *testing.T, loader Loader, typeModules, globalModules []module.Module, opts ...func(*GeneratorOption)) *Generator {
 	t.Helper()
 
 	inflector, err := internal.NewInflector(nil)
@@ -43,20 +64,31 @@ func newTestGenerator(t *testing.T) *Generator {
 		t.Fatalf("failed to create inflector: %v", err)
 	}
 
-	return NewGenerator(&fakeLoader{}, inflector, GeneratorOption{
+	opt := GeneratorOption{
 		PackageName:    "yotest",
 		Tags:           "",
 		FilenameSuffix: ".yo.go",
 		BaseDir:        t.TempDir(),
-	})
+		HeaderModule:   builtin.Header,
+		TypeModules:    typeModules,
+		GlobalModules:  globalModules,
+	}
+	for _, o := range opts {
+		o(&opt)
+	}
+
+	return NewGenerator(loader, inflector, opt)
 }
 
 func TestGenerator(t *testing.T) {
 	table := []struct {
 		name             string
 		schema           *models.Schema
+		typeModules      []module.Module
+		globalModules    []module.Module
 		expectedFilesDir string
 		compareBaseFile  bool
+		opt              func(*GeneratorOption)
 	}{
 		{
 			name:             "BaseOnly",
@@ -64,12 +96,45 @@ func TestGenerator(t *testing.T) {
 			expectedFilesDir: "testdata/empty",
 			compareBaseFile:  true,
 		},
+		{
+			name:             "PostgreSQL",
+			schema:           postgreSQLSchema(),
+			typeModules:      []module.Module{builtin.Type, builtin.Operation, builtin.Index, builtin.ForeignKey},
+			globalModules:    []module.Module{builtin.ChangeStream},
+			expectedFilesDir: "testdata/postgresql",
+		},
+		{
+			name:             "SingleFile",
+			schema:           postgreSQLSchema(),
+			typeModules:      []module.Module{builtin.Type, builtin.Operation, builtin.Index, builtin.ForeignKey},
+			globalModules:    []module.Module{builtin.Interface, builtin.ChangeStream},
+			expectedFilesDir: "testdata/single_file",
+			compareBaseFile:  true,
+			opt: func(opt *GeneratorOption) {
+				opt.SingleFile = true
+				opt.Filename = "yotest.yo.go"
+			},
+		},
+		{
+			name:             "TableFilename",
+			schema:           postgreSQLSchema(),
+			typeModules:      []module.Module{builtin.Type},
+			globalModules:    []module.Module{builtin.ChangeStream},
+			expectedFilesDir: "testdata/table_filename",
+			opt: func(opt *GeneratorOption) {
+				opt.FilenameStrategy = tableFilename
+			},
+		},
 	}
 
 	for _, tc := range table {
 		t.Run(tc.name, func(t *testing.T) {
-			g := newTestGenerator(t)
-			if err := g.Generate(tc.schema); err != nil {
+			var opts []func(*GeneratorOption)
+			if tc.opt != nil {
+				opts = append(opts, tc.opt)
+			}
+			g := newTestGenerator(t, &fakeLoader{dialect: tc.schema.Dialect}, tc.typeModules, tc.globalModules, opts...)
+			if err := g.Generate(context.Background(), tc.schema); err != nil {
 				t.Fatalf("failed to generate: %v", err)
 			}
 
@@ -116,3 +181,494 @@ func TestGenerator(t *testing.T) {
 		})
 	}
 }
+
+// countingModule is a module counting the calls of Load.
+type countingModule struct {
+	module.Module
+	loads int
+}
+
+func (m *countingModule) Load() ([]byte, error) {
+	m.loads++
+	return m.Module.Load()
+}
+
+func TestGenerator_LoadTemplatesOnce(t *testing.T) {
+	typeModule := &countingModule{Module: builtin.Type}
+	globalModule := &countingModule{Module: builtin.Interface}
+	g := newTestGenerator(t, &fakeLoader{}, []module.Module{typeModule}, []module.Module{globalModule})
+	if err := g.Generate(context.Background(), postgreSQLSchema()); err != nil {
+		t.Fatalf("failed to generate: %v", err)
+	}
+
+	if typeModule.loads != 1 {
+		t.Errorf("expected the type module to be loaded once, but got %d", typeModule.loads)
+	}
+	if globalModule.loads != 1 {
+		t.Errorf("expected the global module to be loaded once, but got %d", globalModule.loads)
+	}
+}
+
+func TestGenerator_Check(t *testing.T) {
+	typeModules := []module.Module{builtin.Type}
+	globalModules := []module.Module{builtin.Interface}
+	g := newTestGenerator(t, &fakeLoader{}, typeModules, globalModules)
+	if err := g.Generate(context.Background(), postgreSQLSchema()); err != nil {
+		t.Fatalf("failed to generate: %v", err)
+	}
+	baseDir := g.baseDir
+
+	check := func(t *testing.T) (string, error) {
+		t.Helper()
+
+		out := new(bytes.Buffer)
+		g := newTestGenerator(t, &fakeLoader{}, typeModules, globalModules, func(opt *GeneratorOption) {
+			opt.BaseDir = baseDir
+			opt.Check = true
+			opt.CheckOutput = out
+		})
+		err := g.Generate(context.Background(), postgreSQLSchema())
+		return out.String(), err
+	}
+
+	t.Run("UpToDate", func(t *testing.T) {
+		out, err := check(t)
+		if err != nil {
+			t.Fatalf("expected no error, but got %v", err)
+		}
+		if out != "" {
+			t.Errorf("expected no output, but got %q", out)
+		}
+	})
+
+	t.Run("NotUpToDate", func(t *testing.T) {
+		singer := filepath.Join(baseDir, "singer.yo.go")
+		b, err := os.ReadFile(singer)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(singer, bytes.Replace(b, []byte("Rating"), []byte("Score"), 1), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Remove(filepath.Join(baseDir, "album.yo.go")); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(baseDir, "label.yo.go"), []byte(generatedMarker+"\n\npackage yotest\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(baseDir, "helper.go"), []byte("package yotest\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		out, err := check(t)
+		if !errors.Is(err, ErrNotUpToDate) {
+			t.Fatalf("expected ErrNotUpToDate, but got %v", err)
+		}
+		if expected := "generated code is not up to date: run generate without the check mode to update the files"; err.Error() != expected {
+			t.Errorf("expected error %q, but got %q", expected, err.Error())
+		}
+
+		for _, s := range []string{
+			"--- /dev/null\n+++ " + filepath.Join(baseDir, "album.yo.go") + "\n",
+			"--- " + singer + "\n+++ " + singer + "\n",
+			"-\tScore ",
+			"+\tRating ",
+			filepath.Join(baseDir, "album.yo.go") + " would be created\n",
+			singer + " is out of date\n",
+			filepath.Join(baseDir, "label.yo.go") + " is stale\n",
+		} {
+			if !strings.Contains(out, s) {
+				t.Errorf("expected the output to contain %q, but got\n%s", s, out)
+			}
+		}
+
+		if strings.Contains(out, "helper.go") {
+			t.Errorf("expected the hand-written file not to be reported, but got\n%s", out)
+		}
+
+		// the files are not changed
+		if _, err := os.Stat(filepath.Join(baseDir, "album.yo.go")); !os.IsNotExist(err) {
+			t.Errorf("expected album.yo.go not to be created, but got %v", err)
+		}
+	})
+}
+
+func TestGenerator_Prune(t *testing.T) {
+	baseDir := t.TempDir()
+	files := map[string]string{
+		"label.yo.go":   generatedMarker + "\n\npackage yotest\n",
+		"old_label.go":  "// Copyright\n\n" + generatedMarker + "\n\npackage yotest\n",
+		"helper.go":     "package yotest\n\n" + generatedMarker + "\n",
+		"helper.yo.go":  "package yotest\n",
+		"README.md":     generatedMarker + "\n",
+		"singer.yo.go":  generatedMarker + "\n\npackage yotest\n",
+		"testdata.json": "{}",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(baseDir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	table := []struct {
+		name     string
+		prune    bool
+		expected []string
+	}{
+		{
+			name:     "NoPrune",
+			prune:    false,
+			expected: []string{"README.md", "album.yo.go", "helper.go", "helper.yo.go", "label.yo.go", "old_label.go", "singer.yo.go", "testdata.json"},
+		},
+		{
+			name:     "Prune",
+			prune:    true,
+			expected: []string{"README.md", "album.yo.go", "helper.go", "helper.yo.go", "singer.yo.go", "testdata.json"},
+		},
+	}
+
+	for _, tc := range table {
+		t.Run(tc.name, func(t *testing.T) {
+			g := newTestGenerator(t, &fakeLoader{}, []module.Module{builtin.Type}, nil, func(opt *GeneratorOption) {
+				opt.BaseDir = baseDir
+				opt.Prune = tc.prune
+			})
+			if err := g.Generate(context.Background(), postgreSQLSchema()); err != nil {
+				t.Fatalf("failed to generate: %v", err)
+			}
+
+			entries, err := os.ReadDir(baseDir)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			for _, e := range entries {
+				got = append(got, e.Name())
+			}
+			if diff := cmp.Diff(got, tc.expected); diff != "" {
+				t.Errorf("(-got, +want)\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGenerator_EnumColumn(t *testing.T) {
+	// the name of the column is the same as the unqualified name of the enum
+	id := &models.Field{Name: "ID", Type: "int64", OriginalType: "int64", NullValue: "0", Len: -1, ColumnName: "ID", SpannerDataType: "INT64", IsNotNull: true, IsPrimaryKey: true, Tags: columnTags("ID")}
+	genre := &models.Field{Name: "Genre", Type: "musicpb.Genre", OriginalType: "musicpb.Genre", NullValue: "0", Len: -1, ColumnName: "Genre", SpannerDataType: "Genre", IsNotNull: true, ProtoKind: "ENUM", ProtoName: "Genre", ImportPath: "example.com/musicpb", Tags: columnTags("Genre")}
+	schema := &models.Schema{
+		Types: []*models.Type{
+			{
+				Name:             "Song",
+				TableName:        "Songs",
+				PrimaryKeyFields: []*models.Field{id},
+				Fields:           []*models.Field{id, genre},
+			},
+		},
+	}
+
+	g := newTestGenerator(t, &fakeLoader{}, []module.Module{builtin.Type}, nil, func(opt *GeneratorOption) {
+		opt.DisableFormat = true
+	})
+	if err := g.Generate(context.Background(), schema); err != nil {
+		t.Fatalf("failed to generate: %v", err)
+	}
+
+	b, err := os.ReadFile(filepath.Join(g.baseDir, "song.yo.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := "Genre musicpb.Genre `spanner:\"Genre\" json:\"Genre\"` // Genre\n"
+	if !strings.Contains(string(b), expected) {
+		t.Errorf("expected the file to contain %q, but got:\n%s", expected, b)
+	}
+}
+
+func TestGenerator_ValidateInsertWithDefaults(t *testing.T) {
+	// Price is left to its DEFAULT value by InsertWithDefaults
+	id := &models.Field{Name: "ID", Type: "int64", OriginalType: "int64", NullValue: "0", Len: -1, ColumnName: "ID", SpannerDataType: "INT64", IsNotNull: true, IsPrimaryKey: true, Tags: columnTags("ID")}
+	name := &models.Field{Name: "Name", Type: "string", OriginalType: "string", NullValue: "\"\"", Len: 8, ColumnName: "Name", SpannerDataType: "STRING(8)", IsNotNull: true, Tags: columnTags("Name")}
+	price := &models.Field{Name: "Price", Type: "int64", OriginalType: "int64", NullValue: "0", Len: -1, ColumnName: "Price", SpannerDataType: "INT64", IsNotNull: true, HasDefault: true, DefaultExpr: "1", Tags: columnTags("Price")}
+	schema := &models.Schema{
+		Types: []*models.Type{
+			{
+				Name:             "Item",
+				TableName:        "Items",
+				PrimaryKeyFields: []*models.Field{id},
+				Fields:           []*models.Field{id, name, price},
+				CheckConstraints: []*models.CheckConstraint{{Name: "PricePositive", Expr: "Price > 0"}},
+			},
+		},
+	}
+
+	g := newTestGenerator(t, &fakeLoader{}, []module.Module{builtin.Operation, builtin.Validate}, nil, func(opt *GeneratorOption) {
+		opt.ValidateMutations = true
+	})
+	if err := g.Generate(context.Background(), schema); err != nil {
+		t.Fatalf("failed to generate: %v", err)
+	}
+
+	b, err := os.ReadFile(filepath.Join(g.baseDir, "item.yo.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, expected := range []string{
+		"cols := []string{\n\t\t\"ID\",\n\t\t\"Name\",\n\t}\n\n\tif err := i.validateColumns(cols); err != nil {\n",
+		"return i.validateColumns(ItemWritableColumns())\n",
+		"if written[\"Name\"] {\n",
+		"if written[\"Price\"] && !(i.Price > 0) {\n",
+	} {
+		if !strings.Contains(string(b), expected) {
+			t.Errorf("expected the file to contain %q, but got:\n%s", expected, b)
+		}
+	}
+}
+
+func TestGenerator_IsExpiredCustomType(t *testing.T) {
+	id := &models.Field{Name: "ID", Type: "int64", OriginalType: "int64", NullValue: "0", Len: -1, ColumnName: "ID", SpannerDataType: "INT64", IsNotNull: true, IsPrimaryKey: true, Tags: columnTags("ID")}
+	expiredAt := &models.Field{Name: "ExpiredAt", Type: "ExpiryTime", OriginalType: "time.Time", NullValue: "time.Time{}", Len: -1, ColumnName: "ExpiredAt", SpannerDataType: "TIMESTAMP", IsNotNull: true, Tags: columnTags("ExpiredAt")}
+	deletedAt := &models.Field{Name: "DeletedAt", Type: "NullExpiryTime", OriginalType: "spanner.NullTime", NullValue: "spanner.NullTime{}", Len: -1, ColumnName: "DeletedAt", SpannerDataType: "TIMESTAMP", Tags: columnTags("DeletedAt")}
+
+	table := []struct {
+		name     string
+		field    *models.Field
+		expected []string
+	}{
+		{
+			name:  "NotNull",
+			field: expiredAt,
+			expected: []string{
+				"return now.After(time.Time(i.ExpiredAt).Add(7 * 24 * time.Hour))\n",
+			},
+		},
+		{
+			name:  "Nullable",
+			field: deletedAt,
+			expected: []string{
+				"if !spanner.NullTime(i.DeletedAt).Valid {\n",
+				"return now.After(spanner.NullTime(i.DeletedAt).Time.Add(7 * 24 * time.Hour))\n",
+			},
+		},
+	}
+
+	for _, tc := range table {
+		t.Run(tc.name, func(t *testing.T) {
+			schema := &models.Schema{
+				Types: []*models.Type{
+					{
+						Name:              "Item",
+						TableName:         "Items",
+						PrimaryKeyFields:  []*models.Field{id},
+						Fields:            []*models.Field{id, tc.field},
+						RowDeletionPolicy: &models.RowDeletionPolicy{Field: tc.field, NumDays: 7},
+					},
+				},
+			}
+
+			g := newTestGenerator(t, &fakeLoader{}, []module.Module{builtin.Type}, nil, func(opt *GeneratorOption) {
+				opt.DisableFormat = true
+			})
+			if err := g.Generate(context.Background(), schema); err != nil {
+				t.Fatalf("failed to generate: %v", err)
+			}
+
+			b, err := os.ReadFile(filepath.Join(g.baseDir, "item.yo.go"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, expected := range tc.expected {
+				if !strings.Contains(string(b), expected) {
+					t.Errorf("expected the file to contain %q, but got:\n%s", expected, b)
+				}
+			}
+		})
+	}
+}
+
+// testPluginEnv makes the test binary run as a plugin for TestGenerator_Plugin.
+const testPluginEnv = "YO_GENERATOR_TEST_PLUGIN"
+
+func TestMain(m *testing.M) {
+	if os.Getenv(testPluginEnv) != "" {
+		plugin.Main(testPlugin)
+		return
+	}
+	os.Exit(m.Run())
+}
+
+// testPlugin generates a file listing the tables, a file conflicting with
+// the type module, an error or hangs by the first parameter.
+func testPlugin(req *plugin.Request) (*plugin.Response, error) {
+	schema, err := req.Schema.Models()
+	if err != nil {
+		return nil, err
+	}
+
+	switch req.Options.Parameters[0] {
+	case "conflict":
+		return &plugin.Response{
+			Files: []*plugin.File{{Filename: "album" + req.Options.FilenameSuffix, Content: "var _ = 1\n"}},
+		}, nil
+	case "error":
+		return nil, errors.New("something wrong")
+	case "hang":
+		time.Sleep(time.Minute)
+	}
+
+	var names []string
+	for _, typ := range schema.Types {
+		names = append(names, fmt.Sprintf("%q", typ.TableName))
+	}
+	content := fmt.Sprintf("import \"strings\"\n\nfunc TableNames() string {\nreturn strings.Join([]string{%s}, \",\")\n}\n", strings.Join(names, ", "))
+	return &plugin.Response{
+		Files: []*plugin.File{{Filename: "tables.go", Content: content}},
+	}, nil
+}
+
+func TestGenerator_Plugin(t *testing.T) {
+	t.Setenv(testPluginEnv, "1")
+
+	table := []struct {
+		name    string
+		param   string
+		timeout time.Duration
+		err     string
+	}{
+		{
+			name:  "Files",
+			param: "files",
+		},
+		{
+			name:  "Conflict",
+			param: "conflict",
+			err:   "file album.yo.go of plugin %s is already generated",
+		},
+		{
+			name:  "Error",
+			param: "error",
+			err:   "plugin %s: something wrong",
+		},
+		{
+			name:    "Timeout",
+			param:   "hang",
+			timeout: 500 * time.Millisecond,
+			err:     "plugin %s failed: context deadline exceeded",
+		},
+	}
+
+	for _, tc := range table {
+		t.Run(tc.name, func(t *testing.T) {
+			g := newTestGenerator(t, &fakeLoader{}, []module.Module{builtin.Type}, nil, func(opt *GeneratorOption) {
+				opt.Plugins = []string{os.Args[0]}
+				opt.PluginParameters = []string{tc.param}
+			})
+
+			ctx := context.Background()
+			if tc.timeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, tc.timeout)
+				defer cancel()
+			}
+
+			err := g.Generate(ctx, postgreSQLSchema())
+			if tc.err != "" {
+				expected := fmt.Sprintf(tc.err, os.Args[0])
+				if err == nil || err.Error() != expected {
+					t.Fatalf("expected error %q, but got %v", expected, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("failed to generate: %v", err)
+			}
+
+			b, err := os.ReadFile(filepath.Join(g.baseDir, "tables.go"))
+			if err != nil {
+				t.Fatalf("failed to read the file of the plugin: %v", err)
+			}
+			expected := generatedMarker + `
+
+// Package yotest contains the types.
+package yotest
+
+import (
+	"strings"
+)
+
+func TableNames() string {
+	return strings.Join([]string{"albums", "singers"}, ",")
+}
+`
+			if diff := cmp.Diff(string(b), expected); diff != "" {
+				t.Errorf("(-got, +want)\n%s", diff)
+			}
+		})
+	}
+}
+
+// postgreSQLSchema returns a schema of a PostgreSQL-dialect database.
+func postgreSQLSchema() *models.Schema {
+	singerID := &models.Field{Name: "SingerID", Type: "int64", OriginalType: "int64", NullValue: "0", Len: -1, ColumnName: "singer_id", SpannerDataType: "bigint", IsNotNull: true, IsPrimaryKey: true, Tags: columnTags("singer_id")}
+	firstName := &models.Field{Name: "FirstName", Type: "spanner.NullString", OriginalType: "spanner.NullString", NullValue: "spanner.NullString{}", Len: 256, ColumnName: "FirstName", SpannerDataType: "character varying(256)", Tags: columnTags("FirstName")}
+	rating := &models.Field{Name: "Rating", Type: "spanner.PGNumeric", OriginalType: "spanner.PGNumeric", NullValue: "spanner.PGNumeric{}", Len: -1, ColumnName: "rating", SpannerDataType: "numeric", Tags: columnTags("rating")}
+	singer := &models.Type{
+		Name:             "Singer",
+		TableName:        "singers",
+		PrimaryKeyFields: []*models.Field{singerID},
+		PrimaryKeys:      []*models.IndexKey{{Field: singerID, Desc: true}},
+		Fields:           []*models.Field{singerID, firstName, rating},
+	}
+	singer.Indexes = []*models.Index{
+		{
+			Name:           "SingersByFirstName",
+			FuncName:       "SingersByFirstName",
+			LegacyFuncName: "SingersByFirstName",
+			Type:           singer,
+			Fields:         []*models.Field{firstName},
+			NullableFields: []*models.Field{firstName},
+			Keys:           []*models.IndexKey{{Field: firstName, Desc: true}},
+			IndexName:      "SingersByFirstName",
+			IndexType:      models.IndexTypeIndex,
+		},
+	}
+
+	albumID := &models.Field{Name: "AlbumID", Type: "int64", OriginalType: "int64", NullValue: "0", Len: -1, ColumnName: "album_id", SpannerDataType: "bigint", IsNotNull: true, IsPrimaryKey: true, Tags: columnTags("album_id")}
+	albumSingerID := &models.Field{Name: "SingerID", Type: "int64", OriginalType: "int64", NullValue: "0", Len: -1, ColumnName: "singer_id", SpannerDataType: "bigint", IsNotNull: true, Tags: columnTags("singer_id")}
+	info := &models.Field{Name: "Info", Type: "spanner.PGJsonB", OriginalType: "spanner.PGJsonB", NullValue: "spanner.PGJsonB{}", Len: -1, ColumnName: "info", SpannerDataType: "jsonb", Tags: columnTags("info")}
+	album := &models.Type{
+		Name:             "Album",
+		TableName:        "albums",
+		PrimaryKeyFields: []*models.Field{albumID},
+		PrimaryKeys:      []*models.IndexKey{{Field: albumID}},
+		Fields:           []*models.Field{albumID, albumSingerID, info},
+	}
+	album.ForeignKeys = []*models.ForeignKey{
+		{
+			Name:           "Singer",
+			ConstraintName: "fk_albums_singers",
+			Type:           album,
+			Fields:         []*models.Field{albumSingerID},
+			RefType:        singer,
+			RefFields:      []*models.Field{singerID},
+			OnDeleteAction: "NO ACTION",
+		},
+	}
+
+	stream := &models.ChangeStream{
+		Name:       "SingerStream",
+		StreamName: "SingerStream",
+		Tables:     []*models.ChangeStreamTable{{Type: singer, AllColumns: true}},
+	}
+	singer.ChangeStreams = []*models.ChangeStream{stream}
+
+	return &models.Schema{
+		Types:         []*models.Type{album, singer},
+		ChangeStreams: []*models.ChangeStream{stream},
+		Dialect:       models.DialectPostgreSQL,
+	}
+}
+
+// columnTags returns the default struct tags of the column.
+func columnTags(column string) []*models.Tag {
+	return []*models.Tag{{Key: "spanner", Value: column}, {Key: "json", Value: column}}
+}
@@ -22,6 +22,7 @@ package generator
 import (
 	"fmt"
 	"io"
+	"sync"
 	"text/template"
 
 	"go.mercari.io/yo/v2/models"
@@ -51,6 +52,9 @@ var (
 		"StringSlice": true,
 	}
 
+	// ShortNameTypeMap is the collection of the short names of Go types. It
+	// caches the short names calculated by shortName, and is guarded by
+	// shortNameTypeMapMu while generating code.
 	ShortNameTypeMap = map[string]string{
 		"bool":    "b",
 		"string":  "s",
@@ -70,6 +74,8 @@ var (
 		"float64": "f",
 	}
 
+	shortNameTypeMapMu sync.RWMutex
+
 	ConflictedShortNames = map[string]bool{
 		"context":  true,
 		"errors":   true,
@@ -92,23 +98,48 @@ type basicDataSet struct {
 	Schema   *models.Schema
 }
 
-// templateSet is a set of templates.
+// templateSet is a set of templates. A template is loaded and parsed once per
+// module, and it is safe for concurrent use.
 type templateSet struct {
 	funcs template.FuncMap
+
+	mu        sync.Mutex
+	templates map[module.Module]*template.Template
 }
 
-// Execute executes a specified template in the template set using the supplied
-// obj as its parameters and writing the output to w.
-func (ts *templateSet) Execute(w io.Writer, mod module.Module, obj interface{}) error {
+// lookup returns the parsed template of the module.
+func (ts *templateSet) lookup(mod module.Module) (*template.Template, error) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	if tpl, ok := ts.templates[mod]; ok {
+		return tpl, nil
+	}
+
 	buf, err := mod.Load()
 	if err != nil {
-		return fmt.Errorf("Load module(%s): %v", mod.Name(), err)
+		return nil, fmt.Errorf("Load module(%s): %v", mod.Name(), err)
 	}
 
 	// parse template
 	tpl, err := template.New(mod.Name()).Funcs(ts.funcs).Parse(string(buf))
 	if err != nil {
-		return fmt.Errorf("Parse module(%s): %v", mod.Name(), err)
+		return nil, fmt.Errorf("Parse module(%s): %v", mod.Name(), err)
+	}
+
+	if ts.templates == nil {
+		ts.templates = make(map[module.Module]*template.Template)
+	}
+	ts.templates[mod] = tpl
+	return tpl, nil
+}
+
+// Execute executes a specified template in the template set using the supplied
+// obj as its parameters and writing the output to w.
+func (ts *templateSet) Execute(w io.Writer, mod module.Module, obj interface{}) error {
+	tpl, err := ts.lookup(mod)
+	if err != nil {
+		return err
 	}
 
 	if err := tpl.Execute(w, obj); err != nil {
@@ -0,0 +1,92 @@
+// Code generated by yo. DO NOT EDIT.
+
+// Package yotest contains the types.
+package yotest
+
+import (
+	"fmt"
+
+	"cloud.google.com/go/spanner"
+)
+
+// Album represents a row from 'albums'.
+type Album struct {
+	AlbumID  int64           `spanner:"album_id" json:"album_id"`   // album_id
+	SingerID int64           `spanner:"singer_id" json:"singer_id"` // singer_id
+	Info     spanner.PGJsonB `spanner:"info" json:"info"`           // info
+}
+
+func AlbumPrimaryKeys() []string {
+	return []string{
+		"album_id",
+	}
+}
+
+func AlbumColumns() []string {
+	return []string{
+		"album_id",
+		"singer_id",
+		"info",
+	}
+}
+
+func AlbumWritableColumns() []string {
+	return []string{
+		"album_id",
+		"singer_id",
+		"info",
+	}
+}
+
+func (a *Album) columnsToPtrs(cols []string) ([]interface{}, error) {
+	ret := make([]interface{}, 0, len(cols))
+	for _, col := range cols {
+		switch col {
+		case "album_id":
+			ret = append(ret, yoDecode(&a.AlbumID))
+		case "singer_id":
+			ret = append(ret, yoDecode(&a.SingerID))
+		case "info":
+			ret = append(ret, yoDecode(&a.Info))
+		default:
+			return nil, fmt.Errorf("unknown column: %s", col)
+		}
+	}
+	return ret, nil
+}
+
+func (a *Album) columnsToValues(cols []string) ([]interface{}, error) {
+	ret := make([]interface{}, 0, len(cols))
+	for _, col := range cols {
+		switch col {
+		case "album_id":
+			ret = append(ret, yoEncode(a.AlbumID))
+		case "singer_id":
+			ret = append(ret, yoEncode(a.SingerID))
+		case "info":
+			ret = append(ret, yoEncode(a.Info))
+		default:
+			return nil, fmt.Errorf("unknown column: %s", col)
+		}
+	}
+
+	return ret, nil
+}
+
+// newAlbum_Decoder returns a decoder which reads a row from *spanner.Row
+// into Album. The decoder is not goroutine-safe. Don't use it concurrently.
+func newAlbum_Decoder(cols []string) func(*spanner.Row) (*Album, error) {
+	return func(row *spanner.Row) (*Album, error) {
+		var a Album
+		ptrs, err := a.columnsToPtrs(cols)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := row.Columns(ptrs...); err != nil {
+			return nil, err
+		}
+
+		return &a, nil
+	}
+}
@@ -0,0 +1,92 @@
+// Code generated by yo. DO NOT EDIT.
+
+// Package yotest contains the types.
+package yotest
+
+import (
+	"fmt"
+
+	"cloud.google.com/go/spanner"
+)
+
+// Singer represents a row from 'singers'.
+type Singer struct {
+	SingerID  int64              `spanner:"singer_id" json:"singer_id"` // singer_id
+	FirstName spanner.NullString `spanner:"FirstName" json:"FirstName"` // FirstName
+	Rating    spanner.PGNumeric  `spanner:"rating" json:"rating"`       // rating
+}
+
+func SingerPrimaryKeys() []string {
+	return []string{
+		"singer_id",
+	}
+}
+
+func SingerColumns() []string {
+	return []string{
+		"singer_id",
+		"FirstName",
+		"rating",
+	}
+}
+
+func SingerWritableColumns() []string {
+	return []string{
+		"singer_id",
+		"FirstName",
+		"rating",
+	}
+}
+
+func (s *Singer) columnsToPtrs(cols []string) ([]interface{}, error) {
+	ret := make([]interface{}, 0, len(cols))
+	for _, col := range cols {
+		switch col {
+		case "singer_id":
+			ret = append(ret, yoDecode(&s.SingerID))
+		case "FirstName":
+			ret = append(ret, yoDecode(&s.FirstName))
+		case "rating":
+			ret = append(ret, yoDecode(&s.Rating))
+		default:
+			return nil, fmt.Errorf("unknown column: %s", col)
+		}
+	}
+	return ret, nil
+}
+
+func (s *Singer) columnsToValues(cols []string) ([]interface{}, error) {
+	ret := make([]interface{}, 0, len(cols))
+	for _, col := range cols {
+		switch col {
+		case "singer_id":
+			ret = append(ret, yoEncode(s.SingerID))
+		case "FirstName":
+			ret = append(ret, yoEncode(s.FirstName))
+		case "rating":
+			ret = append(ret, yoEncode(s.Rating))
+		default:
+			return nil, fmt.Errorf("unknown column: %s", col)
+		}
+	}
+
+	return ret, nil
+}
+
+// newSinger_Decoder returns a decoder which reads a row from *spanner.Row
+// into Singer. The decoder is not goroutine-safe. Don't use it concurrently.
+func newSinger_Decoder(cols []string) func(*spanner.Row) (*Singer, error) {
+	return func(row *spanner.Row) (*Singer, error) {
+		var s Singer
+		ptrs, err := s.columnsToPtrs(cols)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := row.Columns(ptrs...); err != nil {
+			return nil, err
+		}
+
+		return &s, nil
+	}
+}
@@ -10,6 +10,7 @@ require (
 	github.com/googleapis/gax-go/v2 v2.14.1
 	github.com/jinzhu/inflection v1.0.0
 	github.com/kenshaw/snaker v0.2.0
+	github.com/pmezard/go-difflib v1.0.0
 	github.com/spf13/cobra v1.7.0
 	golang.org/x/tools v0.21.1-0.20240508182429-e35e4ccd0d2d
 	google.golang.org/api v0.222.0
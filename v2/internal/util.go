@@ -21,6 +21,7 @@ package internal
 
 import (
 	"fmt"
+	"regexp"
 	"strings"
 
 	"github.com/kenshaw/snaker"
@@ -74,3 +75,39 @@ func EscapeColumnName(s string) string {
 	// return s if not reserved keyword
 	return s
 }
+
+// EscapeTableName will escape a table name for queries. A fully qualified name of a table in a named
+// schema is always surrounded by back quotes part by part.
+func EscapeTableName(s string) string {
+	parts := strings.Split(s, ".")
+	if len(parts) == 1 {
+		return EscapeColumnName(s)
+	}
+
+	for i, p := range parts {
+		parts[i] = fmt.Sprintf("`%s`", p)
+	}
+	return strings.Join(parts, ".")
+}
+
+var pgIdentifierRegexp = regexp.MustCompile(`^[a-z_][a-z0-9_]*$`)
+
+// EscapePGIdentifier will escape an identifier of PostgreSQL if it is a reserved keyword or it is
+// folded into lower case without quotes, returning it in surrounded double quotes. The double quotes
+// are escaped by backslashes to be embedded in a Go string literal.
+func EscapePGIdentifier(s string) string {
+	if _, ok := pgReservedKeywords[strings.ToUpper(s)]; ok || !pgIdentifierRegexp.MatchString(s) {
+		return fmt.Sprintf(`\"%s\"`, strings.ReplaceAll(s, `"`, `\"\"`))
+	}
+	return s
+}
+
+// EscapePGTableName will escape a table name for queries of PostgreSQL. A table in a named schema
+// is qualified by the schema name.
+func EscapePGTableName(s string) string {
+	parts := strings.Split(s, ".")
+	for i, p := range parts {
+		parts[i] = EscapePGIdentifier(p)
+	}
+	return strings.Join(parts, ".")
+}
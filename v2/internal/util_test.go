@@ -0,0 +1,46 @@
+// Copyright (c) 2020 Mercari, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package internal
+
+import (
+	"testing"
+)
+
+func TestEscapePGTableName(t *testing.T) {
+	table := []struct {
+		name     string
+		expected string
+	}{
+		{name: "singers", expected: `singers`},
+		{name: "Singers", expected: `\"Singers\"`},
+		{name: "user", expected: `\"user\"`},
+		{name: "first_name1", expected: `first_name1`},
+		{name: "music.singers", expected: `music.singers`},
+		{name: "Music.Singers", expected: `\"Music\".\"Singers\"`},
+	}
+
+	for _, tc := range table {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := EscapePGTableName(tc.name); got != tc.expected {
+				t.Errorf("expect %s, but got %s", tc.expected, got)
+			}
+		})
+	}
+}
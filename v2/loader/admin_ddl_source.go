@@ -0,0 +1,67 @@
+// Copyright (c) 2020 Mercari, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package loader
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	database "cloud.google.com/go/spanner/admin/database/apiv1"
+	"cloud.google.com/go/spanner/admin/database/apiv1/databasepb"
+)
+
+// NewAdminDDLSource creates a SchemaSource from the DDL statements of the
+// database returned by GetDatabaseDdl of the Database Admin API. It only
+// requires the spanner.databases.get and spanner.databases.getDdl
+// permissions. The statements are parsed as GoogleSQL in the same way as
+// NewSchemaParserSource, so the database must be a GoogleSQL database.
+func NewAdminDDLSource(ctx context.Context, client *database.DatabaseAdminClient, db string) (SchemaSource, error) {
+	d, err := client.GetDatabase(ctx, &databasepb.GetDatabaseRequest{
+		Name: db,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get database %s: %v", db, err)
+	}
+	if d.GetDatabaseDialect() == databasepb.DatabaseDialect_POSTGRESQL {
+		return nil, fmt.Errorf("admin-ddl source supports only GoogleSQL databases: %s is a PostgreSQL database", db)
+	}
+
+	res, err := client.GetDatabaseDdl(ctx, &databasepb.GetDatabaseDdlRequest{
+		Database: db,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DDL of %s: %v", db, err)
+	}
+
+	// The statements are joined in the same layout as
+	// `gcloud spanner databases ddl describe`, so positions in errors match
+	// its output.
+	s := newSchemaParserSource()
+	if err := s.parse(db, strings.Join(res.GetStatements(), ";\n\n")); err != nil {
+		return nil, err
+	}
+
+	if err := s.validate(); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
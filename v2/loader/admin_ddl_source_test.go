@@ -0,0 +1,174 @@
+// Copyright (c) 2020 Mercari, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package loader
+
+import (
+	"context"
+	"net"
+	"strings"
+	"testing"
+
+	database "cloud.google.com/go/spanner/admin/database/apiv1"
+	"cloud.google.com/go/spanner/admin/database/apiv1/databasepb"
+	"google.golang.org/api/option"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+
+	"go.mercari.io/yo/v2/internal"
+)
+
+const testAdminDatabase = "projects/yo/instances/yo/databases/yo"
+
+// fakeDatabaseAdminServer serves the dialect and the DDL statements of
+// testAdminDatabase.
+type fakeDatabaseAdminServer struct {
+	databasepb.UnimplementedDatabaseAdminServer
+
+	dialect    databasepb.DatabaseDialect
+	statements []string
+}
+
+func (s *fakeDatabaseAdminServer) GetDatabase(ctx context.Context, req *databasepb.GetDatabaseRequest) (*databasepb.Database, error) {
+	if req.GetName() != testAdminDatabase {
+		return nil, status.Errorf(codes.NotFound, "database not found: %s", req.GetName())
+	}
+	return &databasepb.Database{Name: testAdminDatabase, DatabaseDialect: s.dialect}, nil
+}
+
+func (s *fakeDatabaseAdminServer) GetDatabaseDdl(ctx context.Context, req *databasepb.GetDatabaseDdlRequest) (*databasepb.GetDatabaseDdlResponse, error) {
+	if req.GetDatabase() != testAdminDatabase {
+		return nil, status.Errorf(codes.NotFound, "database not found: %s", req.GetDatabase())
+	}
+	return &databasepb.GetDatabaseDdlResponse{Statements: s.statements}, nil
+}
+
+func newFakeDatabaseAdminClient(t *testing.T, dialect databasepb.DatabaseDialect, statements []string) *database.DatabaseAdminClient {
+	t.Helper()
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+
+	srv := grpc.NewServer()
+	databasepb.RegisterDatabaseAdminServer(srv, &fakeDatabaseAdminServer{dialect: dialect, statements: statements})
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+
+	t.Setenv("SPANNER_EMULATOR_HOST", "")
+	client, err := database.NewDatabaseAdminClient(context.Background(),
+		option.WithEndpoint(lis.Addr().String()),
+		option.WithoutAuthentication(),
+		option.WithGRPCDialOption(grpc.WithTransportCredentials(insecure.NewCredentials())),
+	)
+	if err != nil {
+		t.Fatalf("failed to create admin client: %v", err)
+	}
+	t.Cleanup(func() { client.Close() })
+
+	return client
+}
+
+func TestAdminDDLSource(t *testing.T) {
+	client := newFakeDatabaseAdminClient(t, databasepb.DatabaseDialect_GOOGLE_STANDARD_SQL, []string{
+		"CREATE PROTO BUNDLE (\n  examples.music.Genre,\n)",
+		"CREATE TABLE Singers (\n  SingerId INT64 NOT NULL,\n  Name STRING(MAX),\n) PRIMARY KEY(SingerId)",
+		"CREATE TABLE Albums (\n  SingerId INT64 NOT NULL,\n  AlbumId INT64 NOT NULL,\n  Title STRING(MAX),\n) PRIMARY KEY(SingerId, AlbumId),\n  INTERLEAVE IN PARENT Singers ON DELETE CASCADE",
+		"CREATE INDEX AlbumsByTitle ON Albums(Title)",
+		"CREATE ROLE reader",
+		"GRANT SELECT ON TABLE Singers TO ROLE reader",
+	})
+
+	source, err := NewAdminDDLSource(context.Background(), client, testAdminDatabase)
+	if err != nil {
+		t.Fatalf("failed to create source: %v", err)
+	}
+
+	inflector, err := internal.NewInflector(nil)
+	if err != nil {
+		t.Fatalf("failed to create inflector: %v", err)
+	}
+
+	schema, err := NewTypeLoader(source, inflector, Option{}).LoadSchema()
+	if err != nil {
+		t.Fatalf("failed to load schema: %v", err)
+	}
+
+	var tables []string
+	for _, typ := range schema.Types {
+		var indexes []string
+		for _, ix := range typ.Indexes {
+			indexes = append(indexes, ix.IndexName)
+		}
+		tables = append(tables, typ.TableName+"("+strings.Join(indexes, ",")+")")
+	}
+	if got, want := strings.Join(tables, " "), "Albums(AlbumsByTitle) Singers()"; got != want {
+		t.Errorf("expected tables %q, but got %q", want, got)
+	}
+}
+
+func TestAdminDDLSource_Errors(t *testing.T) {
+	client := newFakeDatabaseAdminClient(t, databasepb.DatabaseDialect_GOOGLE_STANDARD_SQL, []string{
+		"CREATE TABLE Singers (\n  SingerId INT64 NOT NULL,\n) PRIMARY KEY(SingerId)",
+		"ALTER TABLE Albums ADD COLUMN Title STRING(MAX)",
+	})
+
+	table := map[string]struct {
+		database string
+		expected string
+	}{
+		"NotFound": {
+			database: "projects/yo/instances/yo/databases/unknown",
+			expected: "failed to get database projects/yo/instances/yo/databases/unknown: rpc error: code = NotFound desc = database not found: projects/yo/instances/yo/databases/unknown",
+		},
+		"UnknownTable": {
+			database: testAdminDatabase,
+			expected: testAdminDatabase + ":5:1: table Albums is not found: ALTER TABLE Albums ADD COLUMN Title STRING(MAX)",
+		},
+	}
+
+	for name, tc := range table {
+		t.Run(name, func(t *testing.T) {
+			_, err := NewAdminDDLSource(context.Background(), client, tc.database)
+			if err == nil {
+				t.Fatal("expected an error, but got nil")
+			}
+			if err.Error() != tc.expected {
+				t.Errorf("expected error %q, but got %q", tc.expected, err.Error())
+			}
+		})
+	}
+}
+
+func TestAdminDDLSource_PostgreSQL(t *testing.T) {
+	client := newFakeDatabaseAdminClient(t, databasepb.DatabaseDialect_POSTGRESQL, []string{
+		"CREATE TABLE singers (\n  singer_id bigint NOT NULL,\n  PRIMARY KEY(singer_id)\n)",
+	})
+
+	_, err := NewAdminDDLSource(context.Background(), client, testAdminDatabase)
+	if err == nil {
+		t.Fatal("expected an error, but got nil")
+	}
+	if expected := "admin-ddl source supports only GoogleSQL databases: " + testAdminDatabase + " is a PostgreSQL database"; err.Error() != expected {
+		t.Errorf("expected error %q, but got %q", expected, err.Error())
+	}
+}
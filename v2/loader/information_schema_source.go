@@ -21,213 +21,462 @@ package loader
 
 import (
 	"context"
+	"fmt"
 	"os"
 	"sort"
 
 	"cloud.google.com/go/spanner"
-	"google.golang.org/api/iterator"
+
+	"go.mercari.io/yo/v2/models"
 )
 
-func NewInformationSchemaSource(client *spanner.Client) (SchemaSource, error) {
-	return &informationSchemaSource{
+// NewInformationSchemaSource creates a SchemaSource from the information
+// schema of the database. The whole schema is loaded at once in a read-only
+// transaction, so the schema is consistent and the methods of the source are
+// served from memory.
+func NewInformationSchemaSource(ctx context.Context, client *spanner.Client) (SchemaSource, error) {
+	s := &informationSchemaSource{
 		client: client,
-	}, nil
+	}
+
+	txn := client.ReadOnlyTransaction()
+	defer txn.Close()
+
+	dialect, err := s.detectDialect(ctx, txn)
+	if err != nil {
+		return nil, err
+	}
+	s.dialect = dialect
+
+	snapshot, err := s.load(ctx, txn)
+	if err != nil {
+		return nil, err
+	}
+	s.snapshotSource = newSnapshotSource(snapshot)
+
+	return s, nil
 }
 
 type informationSchemaSource struct {
-	client *spanner.Client
-}
+	*snapshotSource
 
-func (s *informationSchemaSource) TableList() ([]*SpannerTable, error) {
-	ctx := context.Background()
+	client  *spanner.Client
+	dialect string
+}
 
-	const sqlstr = `SELECT ` +
-		`TABLE_NAME, PARENT_TABLE_NAME ` +
-		`FROM INFORMATION_SCHEMA.TABLES ` +
-		`WHERE TABLE_SCHEMA = "" ` +
-		`ORDER BY TABLE_NAME`
-	stmt := spanner.NewStatement(sqlstr)
+// query is a query of the information schema written in each of the dialects.
+// The column names of the PostgreSQL query are aliased to the upper case names
+// of the GoogleSQL query. The BOOL columns of GoogleSQL are 'YES' or 'NO' in
+// PostgreSQL, so they are compared with 'YES' to be read as bool.
+type query struct {
+	googleSQL  string
+	postgreSQL string
+}
 
-	iter := s.client.Single().Query(ctx, stmt)
-	defer iter.Stop()
+// param is a query parameter. It is bound by the name for GoogleSQL, and by
+// the position as $1, $2, ... for PostgreSQL.
+type param struct {
+	name  string
+	value interface{}
+}
 
-	var res []*SpannerTable
-	for {
-		row, err := iter.Next()
-		if err != nil {
-			if err == iterator.Done {
-				break
-			}
-			return nil, err
+// newStatement returns a statement of q for the dialect of the database.
+func (s *informationSchemaSource) newStatement(q query, params ...param) spanner.Statement {
+	if s.dialect != models.DialectPostgreSQL {
+		stmt := spanner.NewStatement(q.googleSQL)
+		for _, p := range params {
+			stmt.Params[p.name] = p.value
 		}
+		return stmt
+	}
+
+	stmt := spanner.NewStatement(q.postgreSQL)
+	for i, p := range params {
+		stmt.Params[fmt.Sprintf("p%d", i+1)] = p.value
+	}
+	return stmt
+}
+
+// schemaParam returns the name of schema in the information schema. The
+// default schema is named public in PostgreSQL.
+func (s *informationSchemaSource) schemaParam(schema string) string {
+	if s.dialect == models.DialectPostgreSQL && schema == "" {
+		return "public"
+	}
+	return schema
+}
+
+// schemaName returns the schema name used by the loader for schema in the
+// information schema. The default schema is an empty name.
+func (s *informationSchemaSource) schemaName(schema string) string {
+	if s.dialect == models.DialectPostgreSQL && schema == "public" {
+		return ""
+	}
+	return schema
+}
+
+// query runs q in the transaction and calls fn for each row.
+func (s *informationSchemaSource) query(ctx context.Context, txn *spanner.ReadOnlyTransaction, q query, fn func(row *spanner.Row) error) error {
+	return txn.Query(ctx, s.newStatement(q)).Do(fn)
+}
+
+// detectDialect detects the dialect of the database. The query is valid in
+// both of the dialects.
+func (s *informationSchemaSource) detectDialect(ctx context.Context, txn *spanner.ReadOnlyTransaction) (string, error) {
+	const sqlstr = `SELECT option_value FROM information_schema.database_options ` +
+		`WHERE option_name = 'database_dialect'`
+
+	var dialect string
+	err := txn.Query(ctx, spanner.NewStatement(sqlstr)).Do(func(row *spanner.Row) error {
+		return row.Columns(&dialect)
+	})
+	if err != nil {
+		return "", err
+	}
+
+	if dialect == "" {
+		dialect = models.DialectGoogleSQL
+	}
+	return dialect, nil
+}
+
+func (s *informationSchemaSource) Dialect() (string, error) {
+	return s.dialect, nil
+}
+
+// load loads the whole schema from the information schema. Each of the
+// information schema views is queried only once.
+func (s *informationSchemaSource) load(ctx context.Context, txn *spanner.ReadOnlyTransaction) (*Snapshot, error) {
+	snapshot := &Snapshot{
+		Version: SnapshotVersion,
+		Dialect: s.dialect,
+		Tables:  []*SnapshotTable{},
+	}
+
+	tables, err := s.loadTables(ctx, txn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load tables: %v", err)
+	}
 
+	tableMap := make(map[string]*SnapshotTable)
+	for _, t := range tables {
+		table := &SnapshotTable{SpannerTable: *t}
+		tableMap[qualifiedName(t.TableSchema, t.TableName)] = table
+		snapshot.Tables = append(snapshot.Tables, table)
+	}
+
+	if err := s.loadColumns(ctx, txn, tableMap); err != nil {
+		return nil, fmt.Errorf("failed to load columns: %v", err)
+	}
+	if err := s.loadIndexes(ctx, txn, tableMap); err != nil {
+		return nil, fmt.Errorf("failed to load indexes: %v", err)
+	}
+	if err := s.loadIndexColumns(ctx, txn, tableMap); err != nil {
+		return nil, fmt.Errorf("failed to load index columns: %v", err)
+	}
+	if err := s.loadForeignKeys(ctx, txn, tableMap); err != nil {
+		return nil, fmt.Errorf("failed to load foreign keys: %v", err)
+	}
+	if err := s.loadCheckConstraints(ctx, txn, tableMap); err != nil {
+		return nil, fmt.Errorf("failed to load check constraints: %v", err)
+	}
+
+	snapshot.ChangeStreams, err = s.loadChangeStreams(ctx, txn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load change streams: %v", err)
+	}
+
+	return snapshot, nil
+}
+
+// tableOf returns the table of the row by TABLE_SCHEMA and TABLE_NAME.
+func (s *informationSchemaSource) tableOf(row *spanner.Row, tables map[string]*SnapshotTable) (*SnapshotTable, error) {
+	var schema, name string
+	if err := row.ColumnByName("TABLE_SCHEMA", &schema); err != nil {
+		return nil, err
+	}
+	if err := row.ColumnByName("TABLE_NAME", &name); err != nil {
+		return nil, err
+	}
+
+	return tables[qualifiedName(s.schemaName(schema), name)], nil
+}
+
+func (s *informationSchemaSource) loadTables(ctx context.Context, txn *spanner.ReadOnlyTransaction) ([]*SpannerTable, error) {
+	q := query{
+		googleSQL: `SELECT ` +
+			`TABLE_SCHEMA, TABLE_NAME, TABLE_TYPE, PARENT_TABLE_NAME, ON_DELETE_ACTION, INTERLEAVE_TYPE, ` +
+			`ROW_DELETION_POLICY_EXPRESSION ` +
+			`FROM INFORMATION_SCHEMA.TABLES ` +
+			`WHERE TABLE_SCHEMA NOT IN ("INFORMATION_SCHEMA", "SPANNER_SYS") ` +
+			`ORDER BY TABLE_SCHEMA, TABLE_NAME`,
+		postgreSQL: `SELECT ` +
+			`table_schema AS "TABLE_SCHEMA", table_name AS "TABLE_NAME", table_type AS "TABLE_TYPE", ` +
+			`parent_table_name AS "PARENT_TABLE_NAME", on_delete_action AS "ON_DELETE_ACTION", ` +
+			`interleave_type AS "INTERLEAVE_TYPE", row_deletion_policy_expression AS "ROW_DELETION_POLICY_EXPRESSION" ` +
+			`FROM information_schema.tables ` +
+			`WHERE table_schema NOT IN ('information_schema', 'spanner_sys', 'pg_catalog') ` +
+			`ORDER BY table_schema, table_name`,
+	}
+
+	var res []*SpannerTable
+	err := s.query(ctx, txn, q, func(row *spanner.Row) error {
 		var t SpannerTable
+		if err := row.ColumnByName("TABLE_SCHEMA", &t.TableSchema); err != nil {
+			return err
+		}
+		t.TableSchema = s.schemaName(t.TableSchema)
 		if err := row.ColumnByName("TABLE_NAME", &t.TableName); err != nil {
-			return nil, err
+			return err
 		}
 
+		var tableType string
+		if err := row.ColumnByName("TABLE_TYPE", &tableType); err != nil {
+			return err
+		}
+		t.IsView = tableType == "VIEW"
+
 		var parentTableName spanner.NullString
 		if err := row.ColumnByName("PARENT_TABLE_NAME", &parentTableName); err != nil {
-			return nil, err
+			return err
 		}
 		t.ParentTableName = parentTableName.StringVal
 
+		var onDeleteAction spanner.NullString
+		if err := row.ColumnByName("ON_DELETE_ACTION", &onDeleteAction); err != nil {
+			return err
+		}
+		t.OnDeleteAction = onDeleteAction.StringVal
+
+		var interleaveType spanner.NullString
+		if err := row.ColumnByName("INTERLEAVE_TYPE", &interleaveType); err != nil {
+			return err
+		}
+		t.InterleaveType = interleaveType.StringVal
+
+		var rowDeletionPolicy spanner.NullString
+		if err := row.ColumnByName("ROW_DELETION_POLICY_EXPRESSION", &rowDeletionPolicy); err != nil {
+			return err
+		}
+		t.RowDeletionPolicy = rowDeletionPolicy.StringVal
+
 		res = append(res, &t)
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return res, nil
 }
 
-func (s *informationSchemaSource) ColumnList(table string) ([]*SpannerColumn, error) {
-	ctx := context.Background()
-
+func (s *informationSchemaSource) loadColumns(ctx context.Context, txn *spanner.ReadOnlyTransaction, tables map[string]*SnapshotTable) error {
 	// sql query
-	const sqlstr = `SELECT ` +
-		`c.COLUMN_NAME, c.ORDINAL_POSITION, c.IS_NULLABLE, c.SPANNER_TYPE, ` +
-		`EXISTS (` +
-		`  SELECT 1 FROM INFORMATION_SCHEMA.INDEX_COLUMNS ic ` +
-		`  WHERE ic.TABLE_SCHEMA = "" and ic.TABLE_NAME = c.TABLE_NAME ` +
-		`  AND ic.COLUMN_NAME = c.COLUMN_NAME` +
-		`  AND ic.INDEX_NAME = "PRIMARY_KEY" ` +
-		`) IS_PRIMARY_KEY, ` +
-		`IS_GENERATED = "ALWAYS" AS IS_GENERATED ` +
-		`FROM INFORMATION_SCHEMA.COLUMNS c ` +
-		`WHERE c.TABLE_SCHEMA = "" AND c.TABLE_NAME = @table ` +
-		`ORDER BY c.ORDINAL_POSITION`
-
-	stmt := spanner.NewStatement(sqlstr)
-	stmt.Params["table"] = table
-
-	iter := s.client.Single().Query(ctx, stmt)
-	defer iter.Stop()
-
-	var res []*SpannerColumn
-	for {
-		row, err := iter.Next()
-		if err != nil {
-			if err == iterator.Done {
-				break
-			}
-			return nil, err
+	// A commit timestamp column of PostgreSQL is typed as spanner.commit_timestamp
+	q := query{
+		googleSQL: `SELECT ` +
+			`c.TABLE_SCHEMA, c.TABLE_NAME, ` +
+			`c.COLUMN_NAME, c.ORDINAL_POSITION, c.IS_NULLABLE, c.SPANNER_TYPE, ` +
+			`EXISTS (` +
+			`  SELECT 1 FROM INFORMATION_SCHEMA.INDEX_COLUMNS ic ` +
+			`  WHERE ic.TABLE_SCHEMA = c.TABLE_SCHEMA and ic.TABLE_NAME = c.TABLE_NAME ` +
+			`  AND ic.COLUMN_NAME = c.COLUMN_NAME` +
+			`  AND ic.INDEX_NAME = "PRIMARY_KEY" ` +
+			`) IS_PRIMARY_KEY, ` +
+			`IS_GENERATED = "ALWAYS" AS IS_GENERATED, c.COLUMN_DEFAULT, ` +
+			`EXISTS (` +
+			`  SELECT 1 FROM INFORMATION_SCHEMA.COLUMN_OPTIONS co ` +
+			`  WHERE co.TABLE_SCHEMA = c.TABLE_SCHEMA AND co.TABLE_NAME = c.TABLE_NAME ` +
+			`  AND co.COLUMN_NAME = c.COLUMN_NAME ` +
+			`  AND co.OPTION_NAME = "allow_commit_timestamp" AND co.OPTION_VALUE = "TRUE"` +
+			`) ALLOW_COMMIT_TIMESTAMP ` +
+			`FROM INFORMATION_SCHEMA.COLUMNS c ` +
+			`WHERE c.TABLE_SCHEMA NOT IN ("INFORMATION_SCHEMA", "SPANNER_SYS") ` +
+			`ORDER BY c.TABLE_SCHEMA, c.TABLE_NAME, c.ORDINAL_POSITION`,
+		postgreSQL: `SELECT ` +
+			`c.table_schema AS "TABLE_SCHEMA", c.table_name AS "TABLE_NAME", ` +
+			`c.column_name AS "COLUMN_NAME", c.ordinal_position AS "ORDINAL_POSITION", ` +
+			`c.is_nullable AS "IS_NULLABLE", c.spanner_type AS "SPANNER_TYPE", ` +
+			`EXISTS (` +
+			`  SELECT 1 FROM information_schema.index_columns ic ` +
+			`  WHERE ic.table_schema = c.table_schema AND ic.table_name = c.table_name ` +
+			`  AND ic.column_name = c.column_name ` +
+			`  AND ic.index_name = 'PRIMARY_KEY'` +
+			`) AS "IS_PRIMARY_KEY", ` +
+			`c.is_generated = 'ALWAYS' AS "IS_GENERATED", c.column_default AS "COLUMN_DEFAULT", ` +
+			`c.spanner_type = 'spanner.commit_timestamp' AS "ALLOW_COMMIT_TIMESTAMP" ` +
+			`FROM information_schema.columns c ` +
+			`WHERE c.table_schema NOT IN ('information_schema', 'spanner_sys', 'pg_catalog') ` +
+			`ORDER BY c.table_schema, c.table_name, c.ordinal_position`,
+	}
+
+	return s.query(ctx, txn, q, func(row *spanner.Row) error {
+		t, err := s.tableOf(row, tables)
+		if err != nil || t == nil {
+			return err
 		}
 
 		var c SpannerColumn
 		var ord int64
 		if err := row.ColumnByName("ORDINAL_POSITION", &ord); err != nil {
-			return nil, err
+			return err
 		}
 		c.FieldOrdinal = int(ord)
 		if err := row.ColumnByName("COLUMN_NAME", &c.ColumnName); err != nil {
-			return nil, err
+			return err
 		}
 		var isNullable string
 		if err := row.ColumnByName("IS_NULLABLE", &isNullable); err != nil {
-			return nil, err
+			return err
 		}
 		if isNullable == "NO" {
 			c.NotNull = true
 		}
 		if err := row.ColumnByName("SPANNER_TYPE", &c.DataType); err != nil {
-			return nil, err
+			return err
 		}
 		if err := row.ColumnByName("IS_PRIMARY_KEY", &c.IsPrimaryKey); err != nil {
-			return nil, err
+			return err
 		}
 		if err := row.ColumnByName("IS_GENERATED", &c.IsGenerated); err != nil {
-			return nil, err
+			return err
+		}
+		var columnDefault spanner.NullString
+		if err := row.ColumnByName("COLUMN_DEFAULT", &columnDefault); err != nil {
+			return err
+		}
+		c.HasDefault = columnDefault.Valid
+		c.DefaultExpr = columnDefault.StringVal
+		if err := row.ColumnByName("ALLOW_COMMIT_TIMESTAMP", &c.AllowCommitTimestamp); err != nil {
+			return err
 		}
 
-		res = append(res, &c)
-	}
-
-	return res, nil
+		t.Columns = append(t.Columns, &c)
+		return nil
+	})
 }
 
-func (s *informationSchemaSource) IndexList(table string) ([]*SpannerIndex, error) {
-	ctx := context.Background()
-
+func (s *informationSchemaSource) loadIndexes(ctx context.Context, txn *spanner.ReadOnlyTransaction, tables map[string]*SnapshotTable) error {
 	// sql query
-	const sqlstr = `SELECT ` +
-		`INDEX_NAME, IS_UNIQUE ` +
-		`FROM INFORMATION_SCHEMA.INDEXES ` +
-		`WHERE TABLE_SCHEMA = "" ` +
-		`AND INDEX_NAME != "PRIMARY_KEY" ` +
-		`AND TABLE_NAME = @table ` +
-		`AND SPANNER_IS_MANAGED = FALSE `
-
-	stmt := spanner.NewStatement(sqlstr)
-	stmt.Params["table"] = table
-
-	iter := s.client.Single().Query(ctx, stmt)
-	defer iter.Stop()
-
-	var res []*SpannerIndex
-	for {
-		row, err := iter.Next()
-		if err != nil {
-			if err == iterator.Done {
-				break
-			}
-			return nil, err
+	q := query{
+		googleSQL: `SELECT ` +
+			`TABLE_SCHEMA, TABLE_NAME, INDEX_NAME, INDEX_TYPE, IS_UNIQUE, IS_NULL_FILTERED, PARENT_TABLE_NAME ` +
+			`FROM INFORMATION_SCHEMA.INDEXES ` +
+			`WHERE TABLE_SCHEMA NOT IN ("INFORMATION_SCHEMA", "SPANNER_SYS") ` +
+			`AND INDEX_NAME != "PRIMARY_KEY" ` +
+			`AND SPANNER_IS_MANAGED = FALSE ` +
+			`ORDER BY TABLE_SCHEMA, TABLE_NAME, INDEX_NAME`,
+		postgreSQL: `SELECT ` +
+			`table_schema AS "TABLE_SCHEMA", table_name AS "TABLE_NAME", ` +
+			`index_name AS "INDEX_NAME", index_type AS "INDEX_TYPE", is_unique = 'YES' AS "IS_UNIQUE", ` +
+			`is_null_filtered = 'YES' AS "IS_NULL_FILTERED", parent_table_name AS "PARENT_TABLE_NAME" ` +
+			`FROM information_schema.indexes ` +
+			`WHERE table_schema NOT IN ('information_schema', 'spanner_sys', 'pg_catalog') ` +
+			`AND index_name != 'PRIMARY_KEY' ` +
+			`AND spanner_is_managed = 'NO' ` +
+			`ORDER BY table_schema, table_name, index_name`,
+	}
+
+	return s.query(ctx, txn, q, func(row *spanner.Row) error {
+		t, err := s.tableOf(row, tables)
+		if err != nil || t == nil {
+			return err
 		}
 
-		var i SpannerIndex
+		var i SnapshotIndex
 		if err := row.ColumnByName("INDEX_NAME", &i.IndexName); err != nil {
-			return nil, err
+			return err
 		}
 		if err := row.ColumnByName("IS_UNIQUE", &i.IsUnique); err != nil {
-			return nil, err
+			return err
+		}
+		if err := row.ColumnByName("INDEX_TYPE", &i.IndexType); err != nil {
+			return err
+		}
+		if err := row.ColumnByName("IS_NULL_FILTERED", &i.IsNullFiltered); err != nil {
+			return err
+		}
+		// PARENT_TABLE_NAME is an empty string for an index not interleaved
+		var parentTableName spanner.NullString
+		if err := row.ColumnByName("PARENT_TABLE_NAME", &parentTableName); err != nil {
+			return err
 		}
+		i.ParentTableName = parentTableName.StringVal
 
-		res = append(res, &i)
+		t.Indexes = append(t.Indexes, &i)
+		return nil
+	})
+}
+
+func (s *informationSchemaSource) loadIndexColumns(ctx context.Context, txn *spanner.ReadOnlyTransaction, tables map[string]*SnapshotTable) error {
+	// sql query
+	q := query{
+		googleSQL: `SELECT ` +
+			`TABLE_SCHEMA, TABLE_NAME, INDEX_NAME, ORDINAL_POSITION, COLUMN_NAME, COLUMN_ORDERING ` +
+			`FROM INFORMATION_SCHEMA.INDEX_COLUMNS ` +
+			`WHERE TABLE_SCHEMA NOT IN ("INFORMATION_SCHEMA", "SPANNER_SYS") ` +
+			`ORDER BY TABLE_SCHEMA, TABLE_NAME, INDEX_NAME, ORDINAL_POSITION`,
+		postgreSQL: `SELECT ` +
+			`table_schema AS "TABLE_SCHEMA", table_name AS "TABLE_NAME", index_name AS "INDEX_NAME", ` +
+			`ordinal_position AS "ORDINAL_POSITION", column_name AS "COLUMN_NAME", column_ordering AS "COLUMN_ORDERING" ` +
+			`FROM information_schema.index_columns ` +
+			`WHERE table_schema NOT IN ('information_schema', 'spanner_sys', 'pg_catalog') ` +
+			`ORDER BY table_schema, table_name, index_name, ordinal_position`,
 	}
 
-	return res, nil
-}
+	storing := make(map[*[]*SpannerIndexColumn]bool)
+	err := s.query(ctx, txn, q, func(row *spanner.Row) error {
+		t, err := s.tableOf(row, tables)
+		if err != nil || t == nil {
+			return err
+		}
 
-func (s *informationSchemaSource) IndexColumnList(table string, index string) ([]*SpannerIndexColumn, error) {
-	ctx := context.Background()
+		var index string
+		if err := row.ColumnByName("INDEX_NAME", &index); err != nil {
+			return err
+		}
 
-	// sql query
-	const sqlstr = `SELECT ` +
-		`ORDINAL_POSITION, COLUMN_NAME ` +
-		`FROM INFORMATION_SCHEMA.INDEX_COLUMNS ` +
-		`WHERE TABLE_SCHEMA = "" AND INDEX_NAME = @index AND TABLE_NAME = @table ` +
-		`ORDER BY ORDINAL_POSITION`
-
-	stmt := spanner.NewStatement(sqlstr)
-	stmt.Params["table"] = table
-	stmt.Params["index"] = index
-
-	iter := s.client.Single().Query(ctx, stmt)
-	defer iter.Stop()
-
-	var storing bool
-	var res []*SpannerIndexColumn
-	for {
-		row, err := iter.Next()
-		if err != nil {
-			if err == iterator.Done {
-				break
+		var cols *[]*SpannerIndexColumn
+		if index == "PRIMARY_KEY" {
+			cols = &t.PrimaryKey
+		} else {
+			for _, ix := range t.Indexes {
+				if ix.IndexName == index {
+					cols = &ix.Columns
+					break
+				}
 			}
-			return nil, err
+		}
+		if cols == nil {
+			// a managed index
+			return nil
 		}
 
 		var i SpannerIndexColumn
 		var ord spanner.NullInt64
 		if err := row.ColumnByName("ORDINAL_POSITION", &ord); err != nil {
-			return nil, err
+			return err
 		}
 		i.SeqNo = int(ord.Int64)
 		if !ord.Valid {
 			i.Storing = true
-			storing = true
+			storing[cols] = true
 		}
 		if err := row.ColumnByName("COLUMN_NAME", &i.ColumnName); err != nil {
-			return nil, err
+			return err
 		}
+		// COLUMN_ORDERING is NULL for a storing column
+		var ordering spanner.NullString
+		if err := row.ColumnByName("COLUMN_ORDERING", &ordering); err != nil {
+			return err
+		}
+		i.Desc = ordering.StringVal == "DESC"
 
-		res = append(res, &i)
+		*cols = append(*cols, &i)
+		return nil
+	})
+	if err != nil {
+		return err
 	}
 
 	// Since the value of ORDINAL_POSITION is NULL for the STORING column, the order is undetermined.
@@ -236,10 +485,242 @@ func (s *informationSchemaSource) IndexColumnList(table string, index string) ([
 	// For this reason, only when using the Spanner Emulator are we sorted by column name to fix the order.
 	// In reality, using the Spanner Emulator's Information Schema is not recommended, and this is a measure only for Unit Testing.
 	// https://github.com/cloudspannerecosystem/yo/issues/154
-	if os.Getenv("SPANNER_EMULATOR_HOST") != "" && storing {
-		sort.Slice(res, func(i, j int) bool {
-			return res[i].ColumnName < res[j].ColumnName
-		})
+	if os.Getenv("SPANNER_EMULATOR_HOST") != "" {
+		for cols := range storing {
+			res := *cols
+			sort.Slice(res, func(i, j int) bool {
+				return res[i].ColumnName < res[j].ColumnName
+			})
+		}
+	}
+
+	return nil
+}
+
+func (s *informationSchemaSource) loadForeignKeys(ctx context.Context, txn *spanner.ReadOnlyTransaction, tables map[string]*SnapshotTable) error {
+	// sql query
+	q := query{
+		googleSQL: `SELECT ` +
+			`kcu.TABLE_SCHEMA, kcu.TABLE_NAME, ` +
+			`rc.CONSTRAINT_NAME, rc.DELETE_RULE, kcu.COLUMN_NAME, ` +
+			`rkcu.TABLE_SCHEMA AS REF_TABLE_SCHEMA, rkcu.TABLE_NAME AS REF_TABLE_NAME, rkcu.COLUMN_NAME AS REF_COLUMN_NAME ` +
+			`FROM INFORMATION_SCHEMA.REFERENTIAL_CONSTRAINTS rc ` +
+			`JOIN INFORMATION_SCHEMA.KEY_COLUMN_USAGE kcu ` +
+			`  ON kcu.CONSTRAINT_SCHEMA = rc.CONSTRAINT_SCHEMA AND kcu.CONSTRAINT_NAME = rc.CONSTRAINT_NAME ` +
+			`JOIN INFORMATION_SCHEMA.KEY_COLUMN_USAGE rkcu ` +
+			`  ON rkcu.CONSTRAINT_SCHEMA = rc.UNIQUE_CONSTRAINT_SCHEMA AND rkcu.CONSTRAINT_NAME = rc.UNIQUE_CONSTRAINT_NAME ` +
+			`  AND rkcu.ORDINAL_POSITION = kcu.POSITION_IN_UNIQUE_CONSTRAINT ` +
+			`ORDER BY kcu.TABLE_SCHEMA, kcu.TABLE_NAME, rc.CONSTRAINT_NAME, kcu.ORDINAL_POSITION`,
+		postgreSQL: `SELECT ` +
+			`kcu.table_schema AS "TABLE_SCHEMA", kcu.table_name AS "TABLE_NAME", ` +
+			`rc.constraint_name AS "CONSTRAINT_NAME", rc.delete_rule AS "DELETE_RULE", kcu.column_name AS "COLUMN_NAME", ` +
+			`rkcu.table_schema AS "REF_TABLE_SCHEMA", rkcu.table_name AS "REF_TABLE_NAME", rkcu.column_name AS "REF_COLUMN_NAME" ` +
+			`FROM information_schema.referential_constraints rc ` +
+			`JOIN information_schema.key_column_usage kcu ` +
+			`  ON kcu.constraint_schema = rc.constraint_schema AND kcu.constraint_name = rc.constraint_name ` +
+			`JOIN information_schema.key_column_usage rkcu ` +
+			`  ON rkcu.constraint_schema = rc.unique_constraint_schema AND rkcu.constraint_name = rc.unique_constraint_name ` +
+			`  AND rkcu.ordinal_position = kcu.position_in_unique_constraint ` +
+			`ORDER BY kcu.table_schema, kcu.table_name, rc.constraint_name, kcu.ordinal_position`,
+	}
+
+	return s.query(ctx, txn, q, func(row *spanner.Row) error {
+		t, err := s.tableOf(row, tables)
+		if err != nil || t == nil {
+			return err
+		}
+
+		var constraintName, deleteRule, columnName, refSchema, refTable, refColumnName string
+		if err := row.ColumnByName("CONSTRAINT_NAME", &constraintName); err != nil {
+			return err
+		}
+		if err := row.ColumnByName("DELETE_RULE", &deleteRule); err != nil {
+			return err
+		}
+		if err := row.ColumnByName("COLUMN_NAME", &columnName); err != nil {
+			return err
+		}
+		if err := row.ColumnByName("REF_TABLE_SCHEMA", &refSchema); err != nil {
+			return err
+		}
+		refSchema = s.schemaName(refSchema)
+		if err := row.ColumnByName("REF_TABLE_NAME", &refTable); err != nil {
+			return err
+		}
+		if err := row.ColumnByName("REF_COLUMN_NAME", &refColumnName); err != nil {
+			return err
+		}
+
+		// rows of a foreign key are consecutive since they are ordered by constraint name
+		fks := t.ForeignKeys
+		if len(fks) == 0 || fks[len(fks)-1].ConstraintName != constraintName {
+			t.ForeignKeys = append(t.ForeignKeys, &SpannerForeignKey{
+				ConstraintName: constraintName,
+				RefTableSchema: refSchema,
+				RefTableName:   refTable,
+				OnDeleteAction: deleteRule,
+			})
+		}
+		fk := t.ForeignKeys[len(t.ForeignKeys)-1]
+		fk.ColumnNames = append(fk.ColumnNames, columnName)
+		fk.RefColumnNames = append(fk.RefColumnNames, refColumnName)
+		return nil
+	})
+}
+
+func (s *informationSchemaSource) loadCheckConstraints(ctx context.Context, txn *spanner.ReadOnlyTransaction, tables map[string]*SnapshotTable) error {
+	// sql query
+	// NOT NULL columns are also listed as check constraints named CK_IS_NOT_NULL_*
+	q := query{
+		googleSQL: `SELECT ` +
+			`tc.TABLE_SCHEMA, tc.TABLE_NAME, cc.CONSTRAINT_NAME, cc.CHECK_CLAUSE ` +
+			`FROM INFORMATION_SCHEMA.CHECK_CONSTRAINTS cc ` +
+			`JOIN INFORMATION_SCHEMA.TABLE_CONSTRAINTS tc ` +
+			`  ON tc.CONSTRAINT_SCHEMA = cc.CONSTRAINT_SCHEMA AND tc.CONSTRAINT_NAME = cc.CONSTRAINT_NAME ` +
+			`WHERE tc.CONSTRAINT_TYPE = "CHECK" ` +
+			`AND NOT STARTS_WITH(cc.CONSTRAINT_NAME, "CK_IS_NOT_NULL_") ` +
+			`ORDER BY tc.TABLE_SCHEMA, tc.TABLE_NAME, cc.CONSTRAINT_NAME`,
+		postgreSQL: `SELECT ` +
+			`tc.table_schema AS "TABLE_SCHEMA", tc.table_name AS "TABLE_NAME", ` +
+			`cc.constraint_name AS "CONSTRAINT_NAME", cc.check_clause AS "CHECK_CLAUSE" ` +
+			`FROM information_schema.check_constraints cc ` +
+			`JOIN information_schema.table_constraints tc ` +
+			`  ON tc.constraint_schema = cc.constraint_schema AND tc.constraint_name = cc.constraint_name ` +
+			`WHERE tc.constraint_type = 'CHECK' ` +
+			`AND NOT starts_with(cc.constraint_name, 'CK_IS_NOT_NULL_') ` +
+			`ORDER BY tc.table_schema, tc.table_name, cc.constraint_name`,
+	}
+
+	return s.query(ctx, txn, q, func(row *spanner.Row) error {
+		t, err := s.tableOf(row, tables)
+		if err != nil || t == nil {
+			return err
+		}
+
+		var c SpannerCheckConstraint
+		if err := row.ColumnByName("CONSTRAINT_NAME", &c.ConstraintName); err != nil {
+			return err
+		}
+		if err := row.ColumnByName("CHECK_CLAUSE", &c.CheckClause); err != nil {
+			return err
+		}
+
+		t.CheckConstraints = append(t.CheckConstraints, &c)
+		return nil
+	})
+}
+
+func (s *informationSchemaSource) loadChangeStreams(ctx context.Context, txn *spanner.ReadOnlyTransaction) ([]*SpannerChangeStream, error) {
+	var res []*SpannerChangeStream
+	streams := make(map[string]*SpannerChangeStream)
+
+	streamsQuery := query{
+		googleSQL: `SELECT ` +
+			`CHANGE_STREAM_NAME, ` + "`ALL` " +
+			`FROM INFORMATION_SCHEMA.CHANGE_STREAMS ` +
+			`ORDER BY CHANGE_STREAM_NAME`,
+		postgreSQL: `SELECT ` +
+			`change_stream_name, "all" = 'YES' ` +
+			`FROM information_schema.change_streams ` +
+			`ORDER BY change_stream_name`,
+	}
+	err := s.query(ctx, txn, streamsQuery, func(row *spanner.Row) error {
+		cs := &SpannerChangeStream{
+			Options: make(map[string]string),
+		}
+		if err := row.Columns(&cs.ChangeStreamName, &cs.All); err != nil {
+			return err
+		}
+
+		res = append(res, cs)
+		streams[cs.ChangeStreamName] = cs
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	tablesQuery := query{
+		googleSQL: `SELECT ` +
+			`CHANGE_STREAM_NAME, TABLE_SCHEMA, TABLE_NAME, ALL_COLUMNS ` +
+			`FROM INFORMATION_SCHEMA.CHANGE_STREAM_TABLES ` +
+			`ORDER BY CHANGE_STREAM_NAME, TABLE_SCHEMA, TABLE_NAME`,
+		postgreSQL: `SELECT ` +
+			`change_stream_name, table_schema, table_name, all_columns = 'YES' ` +
+			`FROM information_schema.change_stream_tables ` +
+			`ORDER BY change_stream_name, table_schema, table_name`,
+	}
+	err = s.query(ctx, txn, tablesQuery, func(row *spanner.Row) error {
+		var name string
+		var t SpannerChangeStreamTable
+		if err := row.Columns(&name, &t.TableSchema, &t.TableName, &t.AllColumns); err != nil {
+			return err
+		}
+		t.TableSchema = s.schemaName(t.TableSchema)
+
+		// tables of FOR ALL are not listed as the same as the DDL
+		if cs, ok := streams[name]; ok && !cs.All {
+			cs.Tables = append(cs.Tables, &t)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	columnsQuery := query{
+		googleSQL: `SELECT ` +
+			`CHANGE_STREAM_NAME, TABLE_SCHEMA, TABLE_NAME, COLUMN_NAME ` +
+			`FROM INFORMATION_SCHEMA.CHANGE_STREAM_COLUMNS ` +
+			`ORDER BY CHANGE_STREAM_NAME, TABLE_SCHEMA, TABLE_NAME, COLUMN_NAME`,
+		postgreSQL: `SELECT ` +
+			`change_stream_name, table_schema, table_name, column_name ` +
+			`FROM information_schema.change_stream_columns ` +
+			`ORDER BY change_stream_name, table_schema, table_name, column_name`,
+	}
+	err = s.query(ctx, txn, columnsQuery, func(row *spanner.Row) error {
+		var name, schema, table, column string
+		if err := row.Columns(&name, &schema, &table, &column); err != nil {
+			return err
+		}
+		schema = s.schemaName(schema)
+
+		cs, ok := streams[name]
+		if !ok {
+			return nil
+		}
+		for _, t := range cs.Tables {
+			if t.TableSchema == schema && t.TableName == table && !t.AllColumns {
+				t.ColumnNames = append(t.ColumnNames, column)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	optionsQuery := query{
+		googleSQL: `SELECT ` +
+			`CHANGE_STREAM_NAME, OPTION_NAME, OPTION_VALUE ` +
+			`FROM INFORMATION_SCHEMA.CHANGE_STREAM_OPTIONS`,
+		postgreSQL: `SELECT ` +
+			`change_stream_name, option_name, option_value ` +
+			`FROM information_schema.change_stream_options`,
+	}
+	err = s.query(ctx, txn, optionsQuery, func(row *spanner.Row) error {
+		var name, option, value string
+		if err := row.Columns(&name, &option, &value); err != nil {
+			return err
+		}
+
+		if cs, ok := streams[name]; ok {
+			cs.Options[option] = value
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return res, nil
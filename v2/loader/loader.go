@@ -33,13 +33,38 @@ type Option struct {
 	Config       *config.Config
 	IgnoreFields []string
 	IgnoreTables []string
+
+	// Schemas is the list of schema names to load. All schemas are loaded
+	// if it is empty. The default schema is specified by an empty name.
+	Schemas []string
 }
 
+// SchemaSource provides the schema information. The table name passed to
+// the methods is qualified by the schema name for a table in a named schema.
+// The methods are called for each of the tables and indexes, so a source
+// backed by a database is expected to load the whole schema beforehand and
+// serve the calls from memory.
 type SchemaSource interface {
+	// Dialect returns the dialect of the database, models.DialectGoogleSQL
+	// or models.DialectPostgreSQL. It returns an empty string if the source
+	// cannot detect it.
+	Dialect() (string, error)
 	TableList() ([]*SpannerTable, error)
 	ColumnList(string) ([]*SpannerColumn, error)
 	IndexList(string) ([]*SpannerIndex, error)
 	IndexColumnList(string, string) ([]*SpannerIndexColumn, error)
+	ForeignKeyList(string) ([]*SpannerForeignKey, error)
+	CheckConstraintList(string) ([]*SpannerCheckConstraint, error)
+	ChangeStreamList() ([]*SpannerChangeStream, error)
+}
+
+// positioner is implemented by a SchemaSource which knows where the tables
+// and columns are defined, such as DDL files.
+type positioner interface {
+	// Position returns the position of the table, or the column if column
+	// is not empty, as file:line:column. It returns an empty string if the
+	// position is unknown.
+	Position(table, column string) string
 }
 
 func NewTypeLoader(source SchemaSource, inflector internal.Inflector, opt Option) *TypeLoader {
@@ -54,6 +79,7 @@ func NewTypeLoader(source SchemaSource, inflector internal.Inflector, opt Option
 		config:       cfg,
 		ignoreFields: opt.IgnoreFields,
 		ignoreTables: opt.IgnoreTables,
+		schemas:      opt.Schemas,
 	}
 }
 
@@ -66,24 +92,59 @@ type TypeLoader struct {
 	config       *config.Config
 	ignoreFields []string
 	ignoreTables []string
+	schemas      []string
+
+	// dialect is the dialect of the database. It is set by LoadSchema.
+	dialect string
+
+	// tags builds the struct tags of fields. It is set by tagBuilder.
+	tags *tagBuilder
 }
 
 // NthParam satisifies Loader's NthParam.
 func (tl *TypeLoader) NthParam(i int) string {
+	if tl.dialect == models.DialectPostgreSQL {
+		return fmt.Sprintf("$%d", i+1)
+	}
 	return fmt.Sprintf("@param%d", i)
 }
 
+// NthParamName satisifies Loader's NthParamName.
+func (tl *TypeLoader) NthParamName(i int) string {
+	if tl.dialect == models.DialectPostgreSQL {
+		return fmt.Sprintf("p%d", i+1)
+	}
+	return fmt.Sprintf("param%d", i)
+}
+
 // Mask returns the parameter mask.
 func (tl *TypeLoader) Mask() string {
 	return "?"
 }
 
+// tagBuilder returns the builder of the struct tags defined in the config.
+func (tl *TypeLoader) tagBuilder() (*tagBuilder, error) {
+	if tl.tags == nil {
+		b, err := newTagBuilder(tl.config.Tags)
+		if err != nil {
+			return nil, err
+		}
+		tl.tags = b
+	}
+	return tl.tags, nil
+}
+
 func (tl *TypeLoader) validateCustomType(dataType string, customType string) bool {
 	return true
 }
 
 // LoadSchema loads schema definitions.
 func (tl *TypeLoader) LoadSchema() (*models.Schema, error) {
+	// load dialect
+	if err := tl.LoadDialect(); err != nil {
+		return nil, err
+	}
+
 	// load tables
 	tableMap, err := tl.LoadTable()
 	if err != nil {
@@ -98,6 +159,11 @@ func (tl *TypeLoader) LoadSchema() (*models.Schema, error) {
 
 	setIndexesToTables(tableMap, ixMap)
 
+	// load foreign keys
+	if err := tl.LoadForeignKeys(tableMap); err != nil {
+		return nil, err
+	}
+
 	tables := make([]*models.Type, 0, len(tableMap))
 	for _, tbl := range tableMap {
 		tables = append(tables, tbl)
@@ -107,11 +173,46 @@ func (tl *TypeLoader) LoadSchema() (*models.Schema, error) {
 		return tables[i].Name < tables[j].Name
 	})
 
+	// load change streams
+	changeStreams, err := tl.LoadChangeStreams(tableMap)
+	if err != nil {
+		return nil, err
+	}
+
 	return &models.Schema{
-		Types: tables,
+		Types:         tables,
+		ChangeStreams: changeStreams,
+		Dialect:       tl.dialect,
 	}, nil
 }
 
+// LoadDialect loads the dialect of the database. The dialect in the config is
+// used if the source cannot detect it, otherwise it must match the detected one.
+func (tl *TypeLoader) LoadDialect() error {
+	switch tl.config.Dialect {
+	case "", models.DialectGoogleSQL, models.DialectPostgreSQL:
+	default:
+		return fmt.Errorf("unknown dialect %s: must be %s or %s", tl.config.Dialect, models.DialectGoogleSQL, models.DialectPostgreSQL)
+	}
+
+	dialect, err := tl.source.Dialect()
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case dialect == "" && tl.config.Dialect == "":
+		dialect = models.DialectGoogleSQL
+	case dialect == "":
+		dialect = tl.config.Dialect
+	case tl.config.Dialect != "" && tl.config.Dialect != dialect:
+		return fmt.Errorf("dialect %s in the config does not match the dialect %s of the schema", tl.config.Dialect, dialect)
+	}
+
+	tl.dialect = dialect
+	return nil
+}
+
 // LoadTable loads a schema table/view definition.
 func (tl *TypeLoader) LoadTable() (map[string]*models.Type, error) {
 	var err error
@@ -122,13 +223,44 @@ func (tl *TypeLoader) LoadTable() (map[string]*models.Type, error) {
 		return nil, err
 	}
 
+	// validate tables in the config
+	tableSet := make(map[string]bool)
+	for _, ti := range tableList {
+		tableSet[qualifiedName(ti.TableSchema, ti.TableName)] = true
+	}
+	for _, tbl := range tl.config.Tables {
+		if !tableSet[tbl.Name] {
+			return nil, fmt.Errorf("unknown table %s in the config", tbl.Name)
+		}
+	}
+
+	include, err := compileTablePatterns(tl.config.IncludeTables)
+	if err != nil {
+		return nil, err
+	}
+	exclude, err := compileTablePatterns(tl.config.ExcludeTables)
+	if err != nil {
+		return nil, err
+	}
+
 	// tables
 	tableMap := make(map[string]*models.Type)
+	parentMap := make(map[string]string)
+	typeNames := make(map[string]string)
 	for _, ti := range tableList {
+		if !tl.isTargetSchema(ti.TableSchema) {
+			continue
+		}
+
+		tableName := qualifiedName(ti.TableSchema, ti.TableName)
+		if !isTargetTable(include, exclude, tableName) {
+			continue
+		}
+
 		ignore := false
 
 		for _, ignoreTable := range tl.ignoreTables {
-			if ignoreTable == ti.TableName {
+			if ignoreTable == tableName {
 				// Skip adding this table if user has specified they are not
 				// interested.
 				//
@@ -145,10 +277,13 @@ func (tl *TypeLoader) LoadTable() (map[string]*models.Type, error) {
 
 		// create template
 		typeTpl := &models.Type{
-			Name:      internal.SingularizeIdentifier(tl.inflector, ti.TableName),
-			Fields:    []*models.Field{},
-			TableName: ti.TableName,
-			Parent:    nil,
+			Name:           tl.typeName(ti.TableSchema, ti.TableName),
+			Fields:         []*models.Field{},
+			TableName:      tableName,
+			Schema:         ti.TableSchema,
+			InterleaveType: ti.InterleaveType,
+			OnDeleteAction: ti.OnDeleteAction,
+			IsView:         ti.IsView,
 		}
 
 		// process columns
@@ -157,24 +292,150 @@ func (tl *TypeLoader) LoadTable() (map[string]*models.Type, error) {
 			return nil, err
 		}
 
-		if err := tl.loadPrimaryKeys(typeTpl); err != nil {
-			return nil, err
+		// a view has no primary key and constraints
+		if !typeTpl.IsView {
+			if err := tl.loadPrimaryKeys(typeTpl); err != nil {
+				return nil, err
+			}
+
+			if err := tl.LoadCheckConstraints(typeTpl); err != nil {
+				return nil, err
+			}
+
+			if err := tl.loadRowDeletionPolicy(typeTpl, ti.RowDeletionPolicy); err != nil {
+				return nil, err
+			}
 		}
 
-		tableMap[ti.TableName] = typeTpl
-	}
+		if other, ok := typeNames[typeTpl.Name]; ok {
+			return nil, fmt.Errorf("type name %s is used by both of the tables %s and %s: set typeName in the config", typeTpl.Name, other, tableName)
+		}
+		typeNames[typeTpl.Name] = tableName
 
-	// validate custom type tables
-	for _, customTable := range tl.config.Tables {
-		_, ok := tableMap[customTable.Name]
-		if !ok {
-			return nil, fmt.Errorf("unknown custom type table %s", customTable.Name)
+		tableMap[tableName] = typeTpl
+		if ti.ParentTableName != "" {
+			parentMap[tableName] = qualifiedName(ti.TableSchema, ti.ParentTableName)
 		}
 	}
 
+	setParentsToTables(tableMap, parentMap)
+
 	return tableMap, nil
 }
 
+// errorf returns an error prefixed by the position of the table or the
+// column if the source knows it.
+func (tl *TypeLoader) errorf(table, column, format string, args ...interface{}) error {
+	err := fmt.Errorf(format, args...)
+	if p, ok := tl.source.(positioner); ok {
+		if pos := p.Position(table, column); pos != "" {
+			return fmt.Errorf("%s: %v", pos, err)
+		}
+	}
+	return err
+}
+
+// isTargetSchema reports whether tables in the schema are loaded or not.
+func (tl *TypeLoader) isTargetSchema(schema string) bool {
+	if len(tl.schemas) == 0 {
+		return true
+	}
+
+	for _, s := range tl.schemas {
+		if s == schema {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isTargetTable reports whether the table is loaded or not by the patterns
+// of includeTables and excludeTables in the config.
+func isTargetTable(include, exclude []tablePattern, table string) bool {
+	if len(include) > 0 && !matchAnyPattern(include, table) {
+		return false
+	}
+
+	return !matchAnyPattern(exclude, table)
+}
+
+// tableConfig returns the definitions of the table in the config. It returns
+// nil if the config has no definitions of the table.
+func (tl *TypeLoader) tableConfig(table string) *config.Table {
+	for i := range tl.config.Tables {
+		if tl.config.Tables[i].Name == table {
+			return &tl.config.Tables[i]
+		}
+	}
+
+	return nil
+}
+
+// typeName returns the Go type name for the table. A table in a named schema
+// is prefixed by the schema name to avoid conflicts with other schemas. The
+// typeName in the config is used as it is.
+func (tl *TypeLoader) typeName(schema, table string) string {
+	if tbl := tl.tableConfig(qualifiedName(schema, table)); tbl != nil && tbl.TypeName != "" {
+		return tbl.TypeName
+	}
+
+	name := internal.SingularizeIdentifier(tl.inflector, table)
+	if schema == "" {
+		return name
+	}
+
+	return internal.SnakeToCamel(schema) + name
+}
+
+// LoadCheckConstraints loads CHECK constraints of the table.
+func (tl *TypeLoader) LoadCheckConstraints(typeTpl *models.Type) error {
+	checkList, err := tl.source.CheckConstraintList(typeTpl.TableName)
+	if err != nil {
+		return err
+	}
+
+	for _, c := range checkList {
+		typeTpl.CheckConstraints = append(typeTpl.CheckConstraints, &models.CheckConstraint{
+			Name: c.ConstraintName,
+			Expr: c.CheckClause,
+		})
+	}
+
+	return nil
+}
+
+// loadRowDeletionPolicy loads the row deletion policy of the table from the
+// policy expression. expr is empty if the table has no policy.
+func (tl *TypeLoader) loadRowDeletionPolicy(typeTpl *models.Type, expr string) error {
+	if expr == "" {
+		return nil
+	}
+
+	column, numDays, err := parseRowDeletionPolicy(expr)
+	if err != nil {
+		return tl.errorf(typeTpl.TableName, "", "%v", err)
+	}
+
+	fields := findFields(typeTpl.Fields, []string{column})
+	if len(fields) == 0 {
+		return tl.errorf(typeTpl.TableName, "", "row deletion policy column %s is not found in the table %s", column, typeTpl.TableName)
+	}
+	f := fields[0]
+	switch strings.ToUpper(f.SpannerDataType) {
+	case "TIMESTAMP", "TIMESTAMP WITH TIME ZONE", "SPANNER.COMMIT_TIMESTAMP":
+	default:
+		return tl.errorf(typeTpl.TableName, column, "row deletion policy column %s in the table %s must be TIMESTAMP", column, typeTpl.TableName)
+	}
+
+	typeTpl.RowDeletionPolicy = &models.RowDeletionPolicy{
+		Field:   f,
+		NumDays: numDays,
+	}
+
+	return nil
+}
+
 // loadPrimaryKeys loads primary key fields
 func (tl *TypeLoader) loadPrimaryKeys(typeTpl *models.Type) error {
 	// reorder primary keys
@@ -184,6 +445,7 @@ func (tl *TypeLoader) loadPrimaryKeys(typeTpl *models.Type) error {
 	}
 
 	var fields []*models.Field
+	var keys []*models.IndexKey
 	for _, idx := range indexCols {
 		var field *models.Field
 		for _, f := range typeTpl.Fields {
@@ -194,14 +456,16 @@ func (tl *TypeLoader) loadPrimaryKeys(typeTpl *models.Type) error {
 		}
 
 		if field == nil {
-			return fmt.Errorf("primary key column is not found in column list: table=%v column=%v",
+			return tl.errorf(typeTpl.TableName, "", "primary key column is not found in column list: table=%v column=%v",
 				typeTpl.Name, idx.ColumnName,
 			)
 		}
 		fields = append(fields, field)
+		keys = append(keys, &models.IndexKey{Field: field, Desc: idx.Desc})
 	}
 
 	typeTpl.PrimaryKeyFields = fields
+	typeTpl.PrimaryKeys = keys
 	return nil
 }
 
@@ -214,6 +478,9 @@ func (tl *TypeLoader) tableCustomTypes(table string) map[string]string {
 		}
 
 		for _, col := range tbl.Columns {
+			if col.CustomType == "" {
+				continue
+			}
 			columnTypes[col.Name] = col.CustomType
 		}
 		break
@@ -222,6 +489,155 @@ func (tl *TypeLoader) tableCustomTypes(table string) map[string]string {
 	return columnTypes
 }
 
+// tableCommitTimestamps find commit timestamp definitions of the table
+func (tl *TypeLoader) tableCommitTimestamps(table string) map[string]bool {
+	commitTimestamps := make(map[string]bool)
+	for _, tbl := range tl.config.Tables {
+		if tbl.Name != table {
+			continue
+		}
+
+		for _, col := range tbl.Columns {
+			if col.CommitTimestamp == nil {
+				continue
+			}
+			commitTimestamps[col.Name] = *col.CommitTimestamp
+		}
+		break
+	}
+
+	return commitTimestamps
+}
+
+// protoColumnType is a PROTO or ENUM type of a column resolved by the config.
+type protoColumnType struct {
+	DataType   string // normalized data type such as PROTO<pkg.Msg> or ARRAY<ENUM<pkg.Enum>>
+	Kind       string // PROTO or ENUM
+	Name       string // fully qualified proto name
+	GoType     string // Go type of an element
+	ImportPath string // import path of the Go package of GoType
+	IsArray    bool
+}
+
+// configColumnType is a Go type of a column given by types in the config.
+type configColumnType struct {
+	GoType     string
+	NullValue  string
+	ImportPath string // import path of the Go package of GoType. Empty if GoType is in another package
+}
+
+// resolveConfigType resolves dataType into the Go type given by types in the
+// config. It returns nil if the type is not in the config.
+func (tl *TypeLoader) resolveConfigType(dataType string, nullable bool) *configColumnType {
+	dt := dataType
+	if tl.dialect == models.DialectPostgreSQL {
+		dt = pgSpannerType(dt)
+	}
+	eleType, isArray := arrayElementType(dt)
+	if isArray {
+		dt = eleType
+	}
+	dt = lengthRegexp.ReplaceAllString(dt, "")
+
+	var ct *config.Type
+	for i := range tl.config.Types {
+		if strings.EqualFold(tl.config.Types[i].SpannerType, dt) {
+			ct = &tl.config.Types[i]
+			break
+		}
+	}
+	if ct == nil {
+		return nil
+	}
+
+	typ := ct.GoType
+	if nullable && ct.NullGoType != "" && !isArray {
+		typ = ct.NullGoType
+	}
+
+	res := &configColumnType{GoType: typ, NullValue: typ + "{}"}
+	if isArray {
+		res.GoType = "[]" + typ
+		res.NullValue = "nil"
+		if !nullable {
+			res.NullValue = res.GoType + "{}"
+		}
+	}
+	if pkg := typePackage(typ); pkg != "" && pkg == typePackage(ct.GoType) {
+		res.ImportPath = ct.ImportPath
+	}
+
+	return res
+}
+
+// resolveProtoType resolves dataType into a PROTO or ENUM type. dataType is
+// either PROTO<name> or ENUM<name> from INFORMATION_SCHEMA, or a bare proto
+// name from DDL, optionally in ARRAY<>. It returns nil if dataType is not
+// a proto type.
+func (tl *TypeLoader) resolveProtoType(dataType string) (*protoColumnType, error) {
+	dt := dataType
+	isArray := strings.HasPrefix(dt, "ARRAY<") && strings.HasSuffix(dt, ">")
+	if isArray {
+		dt = strings.TrimSuffix(strings.TrimPrefix(dt, "ARRAY<"), ">")
+	}
+
+	var kind, name string
+	switch {
+	case strings.HasPrefix(dt, "PROTO<") && strings.HasSuffix(dt, ">"):
+		kind, name = "PROTO", dt[len("PROTO<"):len(dt)-1]
+	case strings.HasPrefix(dt, "ENUM<") && strings.HasSuffix(dt, ">"):
+		kind, name = "ENUM", dt[len("ENUM<"):len(dt)-1]
+	default:
+		name = strings.Trim(dt, "`")
+	}
+
+	var pt *config.ProtoType
+	for i := range tl.config.ProtoTypes {
+		if tl.config.ProtoTypes[i].Name == name {
+			pt = &tl.config.ProtoTypes[i]
+			break
+		}
+	}
+
+	if pt == nil {
+		if kind == "" {
+			// a builtin type or an unknown type
+			return nil, nil
+		}
+		return nil, fmt.Errorf("unknown proto type %s: add it to protoTypes in the config", name)
+	}
+
+	if kind == "" {
+		kind = "PROTO"
+		if pt.Enum {
+			kind = "ENUM"
+		}
+	} else if pt.Enum != (kind == "ENUM") {
+		return nil, fmt.Errorf("proto type %s is %s but enum in the config is %t", name, kind, pt.Enum)
+	}
+
+	if i := strings.LastIndex(pt.GoType, "."); i <= 0 || i == len(pt.GoType)-1 {
+		return nil, fmt.Errorf("goType of proto type %s must be qualified by the package name: %q", name, pt.GoType)
+	}
+	if pt.ImportPath == "" {
+		return nil, fmt.Errorf("importPath of proto type %s is required", name)
+	}
+
+	typ := &protoColumnType{
+		DataType:   kind + "<" + name + ">",
+		Kind:       kind,
+		Name:       name,
+		GoType:     pt.GoType,
+		ImportPath: pt.ImportPath,
+		IsArray:    isArray,
+	}
+	if isArray {
+		typ.DataType = "ARRAY<" + typ.DataType + ">"
+	}
+
+	return typ, nil
+}
+
 // LoadColumns loads schema table/view columns.
 func (tl *TypeLoader) LoadColumns(typeTpl *models.Type) error {
 	var err error
@@ -232,23 +648,69 @@ func (tl *TypeLoader) LoadColumns(typeTpl *models.Type) error {
 		return err
 	}
 
+	tags, err := tl.tagBuilder()
+	if err != nil {
+		return err
+	}
+
 	columnTypes := tl.tableCustomTypes(typeTpl.TableName)
+	commitTimestamps := tl.tableCommitTimestamps(typeTpl.TableName)
 
 	// validate custom type columns
-	if columnTypes != nil {
-		columnSet := map[string]struct{}{}
-		for _, column := range columnList {
-			columnSet[column.ColumnName] = struct{}{}
+	columnSet := map[string]*SpannerColumn{}
+	for _, column := range columnList {
+		columnSet[column.ColumnName] = column
+	}
+
+	for k := range columnTypes {
+		if _, ok := columnSet[k]; !ok {
+			return tl.errorf(typeTpl.TableName, "", "unknown custom type column %s in the table %s", k, typeTpl.TableName)
 		}
+	}
 
-		for k := range columnTypes {
-			if _, ok := columnSet[k]; !ok {
-				return fmt.Errorf("unknown custom type column %s in the table %s", k, typeTpl.TableName)
+	// validate commit timestamp columns
+	for k, v := range commitTimestamps {
+		c, ok := columnSet[k]
+		if !ok {
+			return tl.errorf(typeTpl.TableName, "", "unknown commit timestamp column %s in the table %s", k, typeTpl.TableName)
+		}
+		if !v {
+			continue
+		}
+		if !c.AllowCommitTimestamp {
+			return tl.errorf(typeTpl.TableName, k, "column %s in the table %s does not allow commit timestamp", k, typeTpl.TableName)
+		}
+		if c.IsPrimaryKey {
+			return tl.errorf(typeTpl.TableName, k, "commit timestamp cannot be written by mutations into primary key column %s in the table %s", k, typeTpl.TableName)
+		}
+	}
+
+	// validate field names and ignored columns
+	fieldNames := make(map[string]string)
+	tagOverrides := make(map[string]map[string]string)
+	ignoreColumns := make(map[string]bool)
+	if tbl := tl.tableConfig(typeTpl.TableName); tbl != nil {
+		for _, col := range tbl.Columns {
+			if _, ok := columnSet[col.Name]; !ok {
+				return tl.errorf(typeTpl.TableName, "", "unknown column %s in the table %s in the config", col.Name, typeTpl.TableName)
+			}
+			if col.FieldName != "" {
+				fieldNames[col.Name] = col.FieldName
+			}
+			if len(col.Tags) > 0 {
+				tagOverrides[col.Name] = col.Tags
+			}
+		}
+		for _, name := range tbl.IgnoreColumns {
+			if _, ok := columnSet[name]; !ok {
+				return tl.errorf(typeTpl.TableName, "", "unknown ignored column %s in the table %s in the config", name, typeTpl.TableName)
 			}
+			ignoreColumns[name] = true
 		}
 	}
 
 	// process columns
+	fieldColumns := make(map[string]string)
 	for _, c := range columnList {
 		ignore := false
 
@@ -264,25 +726,75 @@ func (tl *TypeLoader) LoadColumns(typeTpl *models.Type) error {
 			}
 		}
 
-		if ignore {
+		if ignore || ignoreColumns[c.ColumnName] {
 			continue
 		}
 
-		len, nilVal, typ := parseSpannerType(c.DataType, !c.NotNull)
+		protoType, err := tl.resolveProtoType(c.DataType)
+		if err != nil {
+			return tl.errorf(typeTpl.TableName, c.ColumnName, "column %s in the table %s: %v", c.ColumnName, typeTpl.TableName, err)
+		}
+
+		// TOKENLIST columns cannot be read, so they are treated as hidden
+		isTokenList := c.DataType == "TOKENLIST"
+		if tl.dialect == models.DialectPostgreSQL {
+			isTokenList = pgSpannerType(c.DataType) == "TOKENLIST"
+		}
+
+		dataType := c.DataType
+		len, nilVal, typ, importPath := -1, "", "", ""
+		if protoType != nil {
+			dataType = protoType.DataType
+			nilVal, typ = parseProtoType(protoType, !c.NotNull)
+			importPath = protoType.ImportPath
+		} else if configType := tl.resolveConfigType(c.DataType, !c.NotNull); configType != nil {
+			nilVal, typ, importPath = configType.NullValue, configType.GoType, configType.ImportPath
+		} else {
+			len, nilVal, typ, err = parseSpannerType(c.DataType, !c.NotNull, tl.dialect)
+			if err != nil {
+				return tl.errorf(typeTpl.TableName, c.ColumnName, "column %s in the table %s: %v", c.ColumnName, typeTpl.TableName, err)
+			}
+			importPath = typeImportPaths[typePackage(typ)]
+		}
+
+		fieldName, ok := fieldNames[c.ColumnName]
+		if !ok {
+			fieldName = internal.SnakeToCamel(c.ColumnName)
+		}
+		if other, ok := fieldColumns[fieldName]; ok {
+			return tl.errorf(typeTpl.TableName, c.ColumnName, "field name %s is used by both of the columns %s and %s in the table %s: set fieldName in the config", fieldName, other, c.ColumnName, typeTpl.TableName)
+		}
+		fieldColumns[fieldName] = c.ColumnName
 
 		// set col info
 		f := &models.Field{
-			Name:            internal.SnakeToCamel(c.ColumnName),
+			Name:            fieldName,
 			Len:             len,
 			NullValue:       nilVal,
 			Type:            typ,
 			OriginalType:    typ,
 			ColumnName:      c.ColumnName,
-			SpannerDataType: c.DataType,
+			SpannerDataType: dataType,
 			IsNotNull:       c.NotNull,
 			IsPrimaryKey:    c.IsPrimaryKey,
 			IsGenerated:     c.IsGenerated,
-			IsHidden:        c.IsHidden,
+			IsHidden:        c.IsHidden || isTokenList,
+			HasDefault:      c.HasDefault,
+			DefaultExpr:     c.DefaultExpr,
+
+			AllowCommitTimestamp: c.AllowCommitTimestamp,
+			UseCommitTimestamp:   tl.config.CommitTimestamp && c.AllowCommitTimestamp && !c.IsPrimaryKey,
+			ImportPath:           importPath,
+		}
+
+		if protoType != nil {
+			f.ProtoKind = protoType.Kind
+			f.ProtoName = protoType.Name
+		}
+
+		// set commit timestamp behavior
+		if useCommitTimestamp, ok := commitTimestamps[c.ColumnName]; ok {
+			f.UseCommitTimestamp = useCommitTimestamp
 		}
 
 		// set custom type
@@ -291,6 +803,12 @@ func (tl *TypeLoader) LoadColumns(typeTpl *models.Type) error {
 			f.Type = customType
 		}
 
+		// set struct tags
+		f.Tags, err = tags.build(f, tagOverrides[c.ColumnName])
+		if err != nil {
+			return tl.errorf(typeTpl.TableName, c.ColumnName, "column %s in the table %s: %v", c.ColumnName, typeTpl.TableName, err)
+		}
+
 		// append col to template fields
 		typeTpl.Fields = append(typeTpl.Fields, f)
 	}
@@ -325,19 +843,52 @@ func (tl *TypeLoader) LoadTableIndexes(typeTpl *models.Type, ixMap map[string]*m
 		return err
 	}
 
+	funcNames := make(map[string]string)
+	if tbl := tl.tableConfig(typeTpl.TableName); tbl != nil {
+		for _, ix := range tbl.Indexes {
+			funcNames[ix.Name] = ix.FuncName
+		}
+	}
+
+	// validate indexes in the config
+	for name := range funcNames {
+		found := false
+		for _, ix := range indexList {
+			found = found || ix.IndexName == name
+		}
+		if !found {
+			return tl.errorf(typeTpl.TableName, "", "unknown index %s in the table %s in the config", name, typeTpl.TableName)
+		}
+	}
+
 	// process indexes
 	for _, ix := range indexList {
 		// save whether or not the primary key index was processed
 		priIxLoaded = priIxLoaded || ix.IsPrimary
 
+		// an index type is empty in a snapshot of an old version
+		indexType := ix.IndexType
+		if indexType == "" {
+			indexType = models.IndexTypeIndex
+		}
+
+		var interleavedIn string
+		if ix.ParentTableName != "" {
+			// a parent table always belongs to the same schema
+			interleavedIn = qualifiedName(typeTpl.Schema, ix.ParentTableName)
+		}
+
 		// create index template
 		ixTpl := &models.Index{
-			Name:      internal.SnakeToCamel(ix.IndexName),
-			Type:      typeTpl,
-			Fields:    []*models.Field{},
-			IndexName: ix.IndexName,
-			IsUnique:  ix.IsUnique,
-			IsPrimary: ix.IsPrimary,
+			Name:          internal.SnakeToCamel(ix.IndexName),
+			Type:          typeTpl,
+			Fields:        []*models.Field{},
+			IndexName:     qualifiedName(typeTpl.Schema, ix.IndexName),
+			IsUnique:      ix.IsUnique,
+			IsPrimary:     ix.IsPrimary,
+			NullFiltered:  ix.IsNullFiltered,
+			InterleavedIn: interleavedIn,
+			IndexType:     indexType,
 		}
 
 		// load index columns
@@ -349,6 +900,10 @@ func (tl *TypeLoader) LoadTableIndexes(typeTpl *models.Type, ixMap map[string]*m
 		// build func name
 		ixTpl.FuncName = tl.buildIndexFuncName(ixTpl)
 		ixTpl.LegacyFuncName = tl.buildLegacyIndexFuncName(ixTpl)
+		if funcName := funcNames[ix.IndexName]; funcName != "" {
+			ixTpl.FuncName = funcName
+			ixTpl.LegacyFuncName = funcName
+		}
 
 		ixMap[typeTpl.TableName+"_"+ix.IndexName] = ixTpl
 	}
@@ -382,7 +937,7 @@ func (tl *TypeLoader) buildIndexFuncName(ixTpl *models.Index) string {
 	if !ixTpl.IsUnique {
 		funcName = tl.inflector.Pluralize(ixTpl.Type.Name)
 	}
-	return funcName + "By" + internal.SnakeToCamel(ixTpl.IndexName)
+	return funcName + "By" + ixTpl.Name
 }
 
 // LoadIndexColumns loads the index column information.
@@ -390,7 +945,8 @@ func (tl *TypeLoader) LoadIndexColumns(ixTpl *models.Index) error {
 	var err error
 
 	// load index columns
-	indexCols, err := tl.source.IndexColumnList(ixTpl.Type.TableName, ixTpl.IndexName)
+	_, indexName := splitQualifiedName(ixTpl.IndexName)
+	indexCols, err := tl.source.IndexColumnList(ixTpl.Type.TableName, indexName)
 	if err != nil {
 		return err
 	}
@@ -417,6 +973,7 @@ func (tl *TypeLoader) LoadIndexColumns(ixTpl *models.Index) error {
 			ixTpl.StoringFields = append(ixTpl.StoringFields, field)
 		} else {
 			ixTpl.Fields = append(ixTpl.Fields, field)
+			ixTpl.Keys = append(ixTpl.Keys, &models.IndexKey{Field: field, Desc: ic.Desc})
 		}
 		if !field.IsNotNull {
 			ixTpl.NullableFields = append(ixTpl.NullableFields, field)
@@ -426,6 +983,171 @@ func (tl *TypeLoader) LoadIndexColumns(ixTpl *models.Index) error {
 	return nil
 }
 
+// LoadForeignKeys loads foreign key definitions of the tables. A foreign key
+// is skipped if the referenced table or any of the columns is excluded.
+func (tl *TypeLoader) LoadForeignKeys(tableMap map[string]*models.Type) error {
+	for _, t := range tableMap {
+		fkList, err := tl.source.ForeignKeyList(t.TableName)
+		if err != nil {
+			return fmt.Errorf("failed to load foreign keys: %v", err)
+		}
+
+		for _, fk := range fkList {
+			refType, ok := tableMap[qualifiedName(fk.RefTableSchema, fk.RefTableName)]
+			if !ok {
+				continue
+			}
+
+			fields := findFields(t.Fields, fk.ColumnNames)
+			refFields := findFields(refType.Fields, fk.RefColumnNames)
+			if fields == nil || refFields == nil {
+				continue
+			}
+
+			t.ForeignKeys = append(t.ForeignKeys, &models.ForeignKey{
+				Name:           refType.Name,
+				ConstraintName: fk.ConstraintName,
+				Type:           t,
+				Fields:         fields,
+				RefType:        refType,
+				RefFields:      refFields,
+				OnDeleteAction: fk.OnDeleteAction,
+			})
+		}
+
+		// disambiguate names of foreign keys referencing the same table
+		refCount := make(map[*models.Type]int)
+		for _, fk := range t.ForeignKeys {
+			refCount[fk.RefType]++
+		}
+		for _, fk := range t.ForeignKeys {
+			if refCount[fk.RefType] < 2 {
+				continue
+			}
+
+			names := make([]string, 0, len(fk.Fields))
+			for _, f := range fk.Fields {
+				names = append(names, f.Name)
+			}
+			fk.Name = fk.RefType.Name + "By" + strings.Join(names, "")
+		}
+	}
+
+	return nil
+}
+
+// LoadChangeStreams loads change stream definitions. The tables of a change
+// stream FOR ALL are all of the tables. A table or a column excluded from the
+// tables is not watched.
+func (tl *TypeLoader) LoadChangeStreams(tableMap map[string]*models.Type) ([]*models.ChangeStream, error) {
+	streamList, err := tl.source.ChangeStreamList()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load change streams: %v", err)
+	}
+
+	var streams []*models.ChangeStream
+	for _, cs := range streamList {
+		stream := &models.ChangeStream{
+			Name:       internal.SnakeToCamel(cs.ChangeStreamName),
+			StreamName: cs.ChangeStreamName,
+			All:        cs.All,
+			Options:    cs.Options,
+		}
+
+		if cs.All {
+			for _, t := range tableMap {
+				if t.IsView {
+					continue
+				}
+				stream.Tables = append(stream.Tables, &models.ChangeStreamTable{
+					Type:       t,
+					AllColumns: true,
+				})
+			}
+		}
+
+		for _, ct := range cs.Tables {
+			t, ok := tableMap[qualifiedName(ct.TableSchema, ct.TableName)]
+			if !ok {
+				continue
+			}
+
+			// keep the order of the table columns
+			var fields []*models.Field
+			for _, f := range t.Fields {
+				for _, c := range ct.ColumnNames {
+					if f.ColumnName == c {
+						fields = append(fields, f)
+						break
+					}
+				}
+			}
+
+			stream.Tables = append(stream.Tables, &models.ChangeStreamTable{
+				Type:       t,
+				AllColumns: ct.AllColumns,
+				Fields:     fields,
+			})
+		}
+
+		sort.Slice(stream.Tables, func(i, j int) bool {
+			return stream.Tables[i].Type.TableName < stream.Tables[j].Type.TableName
+		})
+
+		for _, ct := range stream.Tables {
+			ct.Type.ChangeStreams = append(ct.Type.ChangeStreams, stream)
+		}
+
+		streams = append(streams, stream)
+	}
+
+	return streams, nil
+}
+
+// findFields returns the fields of the columns in the same order.
+// It returns nil if any of the columns is not found.
+func findFields(fields []*models.Field, columns []string) []*models.Field {
+	res := make([]*models.Field, 0, len(columns))
+	for _, c := range columns {
+		var field *models.Field
+		for _, f := range fields {
+			if f.ColumnName == c {
+				field = f
+				break
+			}
+		}
+
+		if field == nil {
+			return nil
+		}
+		res = append(res, field)
+	}
+
+	return res
+}
+
+// setParentsToTables links interleaved tables to their parent tables.
+// A parent table excluded from the tables is not linked.
+func setParentsToTables(tableMap map[string]*models.Type, parentMap map[string]string) {
+	for name, parentName := range parentMap {
+		parent, ok := tableMap[parentName]
+		if !ok {
+			continue
+		}
+
+		t := tableMap[name]
+		t.Parent = parent
+		parent.Children = append(parent.Children, t)
+	}
+
+	// sort by table name
+	for _, t := range tableMap {
+		sort.Slice(t.Children, func(i, j int) bool {
+			return t.Children[i].TableName < t.Children[j].TableName
+		})
+	}
+}
+
 func setIndexesToTables(tableMap map[string]*models.Type, ixMap map[string]*models.Index) {
 	indexes := make([]*models.Index, 0, len(ixMap))
 	for _, ix := range ixMap {
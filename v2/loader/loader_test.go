@@ -22,6 +22,8 @@ package loader
 import (
 	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -58,7 +60,7 @@ CREATE INDEX InterleavedKey ON Interleaved(Id, Value), INTERLEAVE IN Parent
   PKey1 STRING(32) NOT NULL,
   PKey2 STRING(32) NOT NULL,
   PKey3 STRING(32) NOT NULL,
-) PRIMARY KEY(PKey2, PKey1, PKey3);
+) PRIMARY KEY(PKey2, PKey1 DESC, PKey3);
 `
 
 	maxLengthSchema = `
@@ -66,6 +68,18 @@ CREATE TABLE MaxLengths (
   MaxString STRING(MAX) NOT NULL,
   MaxBytes BYTES(MAX) NOT NULL,
 ) PRIMARY KEY(MaxString);
+`
+
+	namedSchema = `
+CREATE SCHEMA billing;
+CREATE TABLE Invoices (
+  Id INT64 NOT NULL,
+) PRIMARY KEY(Id);
+CREATE TABLE billing.Invoices (
+  Id INT64 NOT NULL,
+  Value STRING(32) NOT NULL,
+) PRIMARY KEY(Id);
+CREATE INDEX billing.InvoicesByValue ON billing.Invoices(Value);
 `
 
 	alterTableAddFKSchema = `
@@ -96,6 +110,9 @@ func TestLoader(t *testing.T) {
 						PrimaryKeyFields: []*models.Field{
 							{ColumnName: "Id"},
 						},
+						PrimaryKeys: []*models.IndexKey{
+							{Field: &models.Field{ColumnName: "Id"}},
+						},
 						Fields: []*models.Field{
 							{
 								Name:            "ID",
@@ -129,7 +146,11 @@ func TestLoader(t *testing.T) {
 								Fields: []*models.Field{
 									{ColumnName: "Value"},
 								},
+								Keys: []*models.IndexKey{
+									{Field: &models.Field{ColumnName: "Value"}},
+								},
 								IndexName: "SimpleIndex",
+								IndexType: models.IndexTypeIndex,
 							},
 							{
 								Name:           "SimpleIndex2",
@@ -139,12 +160,18 @@ func TestLoader(t *testing.T) {
 									{ColumnName: "Id"},
 									{ColumnName: "Value"},
 								},
+								Keys: []*models.IndexKey{
+									{Field: &models.Field{ColumnName: "Id"}},
+									{Field: &models.Field{ColumnName: "Value"}},
+								},
 								IndexName: "SimpleIndex2",
+								IndexType: models.IndexTypeIndex,
 								IsUnique:  true,
 							},
 						},
 					},
 				},
+				Dialect: models.DialectGoogleSQL,
 			},
 		},
 		{
@@ -159,6 +186,10 @@ func TestLoader(t *testing.T) {
 							{ColumnName: "Id"},
 							{ColumnName: "InterleavedId"},
 						},
+						PrimaryKeys: []*models.IndexKey{
+							{Field: &models.Field{ColumnName: "Id"}},
+							{Field: &models.Field{ColumnName: "InterleavedId"}},
+						},
 						Fields: []*models.Field{
 							{
 								Name:            "InterleavedID",
@@ -194,7 +225,9 @@ func TestLoader(t *testing.T) {
 								IsPrimaryKey:    false,
 							},
 						},
-						TableName: "Interleaved",
+						TableName:      "Interleaved",
+						InterleaveType: "IN PARENT",
+						OnDeleteAction: "NO ACTION",
 						Indexes: []*models.Index{
 							{
 								Name:           "InterleavedKey",
@@ -204,7 +237,13 @@ func TestLoader(t *testing.T) {
 									{ColumnName: "Id"},
 									{ColumnName: "Value"},
 								},
-								IndexName: "InterleavedKey",
+								Keys: []*models.IndexKey{
+									{Field: &models.Field{ColumnName: "Id"}},
+									{Field: &models.Field{ColumnName: "Value"}},
+								},
+								IndexName:     "InterleavedKey",
+								InterleavedIn: "Parent",
+								IndexType:     models.IndexTypeIndex,
 							},
 						},
 					},
@@ -213,6 +252,9 @@ func TestLoader(t *testing.T) {
 						PrimaryKeyFields: []*models.Field{
 							{ColumnName: "Id"},
 						},
+						PrimaryKeys: []*models.IndexKey{
+							{Field: &models.Field{ColumnName: "Id"}},
+						},
 						Fields: []*models.Field{
 							{
 								Name:            "ID",
@@ -229,6 +271,7 @@ func TestLoader(t *testing.T) {
 						TableName: "Parent",
 					},
 				},
+				Dialect: models.DialectGoogleSQL,
 			},
 		},
 		{
@@ -244,6 +287,11 @@ func TestLoader(t *testing.T) {
 							{ColumnName: "PKey1"},
 							{ColumnName: "PKey3"},
 						},
+						PrimaryKeys: []*models.IndexKey{
+							{Field: &models.Field{ColumnName: "PKey2"}},
+							{Field: &models.Field{ColumnName: "PKey1"}, Desc: true},
+							{Field: &models.Field{ColumnName: "PKey3"}},
+						},
 						Fields: []*models.Field{
 							{
 								Name:            "PKey1",
@@ -282,6 +330,7 @@ func TestLoader(t *testing.T) {
 						TableName: "OutOfOrderPrimaryKeys",
 					},
 				},
+				Dialect: models.DialectGoogleSQL,
 			},
 		},
 		{
@@ -295,6 +344,9 @@ func TestLoader(t *testing.T) {
 						PrimaryKeyFields: []*models.Field{
 							{ColumnName: "MaxString"},
 						},
+						PrimaryKeys: []*models.IndexKey{
+							{Field: &models.Field{ColumnName: "MaxString"}},
+						},
 						Fields: []*models.Field{
 							{
 								Name:            "MaxString",
@@ -322,107 +374,106 @@ func TestLoader(t *testing.T) {
 						TableName: "MaxLengths",
 					},
 				},
+				Dialect: models.DialectGoogleSQL,
 			},
 		},
 		{
-			name:   "AlterTableAddFK",
-			opt:    Option{},
-			schema: alterTableAddFKSchema,
-			expectedSchema: &models.Schema{
-				Types: []*models.Type{},
-			},
-		},
-		{
-			name:   "AlterTableAddConstraintFK",
+			name:   "NamedSchema",
 			opt:    Option{},
-			schema: alterTableAddConstraintFKSchema,
+			schema: namedSchema,
 			expectedSchema: &models.Schema{
-				Types: []*models.Type{},
-			},
-		},
-	}
-
-	for _, tc := range table {
-		t.Run(tc.name, func(t *testing.T) {
-			l := setUpTypeLoader(t, tc.schema, tc.opt)
-
-			schema, err := l.LoadSchema()
-			if err != nil {
-				t.Fatalf("failed to load schema: %v", err)
-			}
-
-			compareSchemas(t, schema, tc.expectedSchema)
-		})
-	}
-}
-
-func TestLoader_CustomTypes(t *testing.T) {
-	table := []struct {
-		name           string
-		opt            Option
-		schema         string
-		expectedSchema *models.Schema
-		expectedErr    string
-	}{
-		{
-			name: "Custom type table does not exist",
-			opt: Option{
-				Config: &config.Config{
-					Tables: []config.Table{
-						{
-							Name: "UnknownTable",
+				Types: []*models.Type{
+					{
+						Name: "BillingInvoice",
+						PrimaryKeyFields: []*models.Field{
+							{ColumnName: "Id"},
 						},
-					},
-				},
-			},
-			schema:      simpleSchema,
-			expectedErr: "unknown custom type table UnknownTable",
-		},
-		{
-			name: "Custom type column does not exist",
-			opt: Option{
-				Config: &config.Config{
-					Tables: []config.Table{
-						{
-							Name: "Simple",
-							Columns: []config.Column{
-								{
-									Name:       "UnknownColumn",
-									CustomType: "UnknownCustomColumn",
+						PrimaryKeys: []*models.IndexKey{
+							{Field: &models.Field{ColumnName: "Id"}},
+						},
+						Fields: []*models.Field{
+							{
+								Name:            "ID",
+								Type:            "int64",
+								OriginalType:    "int64",
+								NullValue:       "0",
+								Len:             -1,
+								ColumnName:      "Id",
+								SpannerDataType: "INT64",
+								IsNotNull:       true,
+								IsPrimaryKey:    true,
+							},
+							{
+								Name:            "Value",
+								Type:            "string",
+								OriginalType:    "string",
+								NullValue:       `""`,
+								Len:             32,
+								ColumnName:      "Value",
+								SpannerDataType: "STRING(32)",
+								IsNotNull:       true,
+								IsPrimaryKey:    false,
+							},
+						},
+						TableName: "billing.Invoices",
+						Schema:    "billing",
+						Indexes: []*models.Index{
+							{
+								Name:           "InvoicesByValue",
+								FuncName:       "BillingInvoicesByInvoicesByValue",
+								LegacyFuncName: "BillingInvoicesByValue",
+								Fields: []*models.Field{
+									{ColumnName: "Value"},
+								},
+								Keys: []*models.IndexKey{
+									{Field: &models.Field{ColumnName: "Value"}},
 								},
+								IndexName: "billing.InvoicesByValue",
+								IndexType: models.IndexTypeIndex,
 							},
 						},
 					},
-				},
-			},
-			schema:      simpleSchema,
-			expectedErr: "unknown custom type column UnknownColumn in the table Simple",
-		},
-		{
-			name: "Success",
-			opt: Option{
-				Config: &config.Config{
-					Tables: []config.Table{
-						{
-							Name: "Simple",
-							Columns: []config.Column{
-								{
-									Name:       "Value",
-									CustomType: "Value",
-								},
+					{
+						Name: "Invoice",
+						PrimaryKeyFields: []*models.Field{
+							{ColumnName: "Id"},
+						},
+						PrimaryKeys: []*models.IndexKey{
+							{Field: &models.Field{ColumnName: "Id"}},
+						},
+						Fields: []*models.Field{
+							{
+								Name:            "ID",
+								Type:            "int64",
+								OriginalType:    "int64",
+								NullValue:       "0",
+								Len:             -1,
+								ColumnName:      "Id",
+								SpannerDataType: "INT64",
+								IsNotNull:       true,
+								IsPrimaryKey:    true,
 							},
 						},
+						TableName: "Invoices",
 					},
 				},
+				Dialect: models.DialectGoogleSQL,
 			},
-			schema: simpleSchema,
+		},
+		{
+			name:   "NamedSchemaAllowList",
+			opt:    Option{Schemas: []string{"billing"}},
+			schema: namedSchema,
 			expectedSchema: &models.Schema{
 				Types: []*models.Type{
 					{
-						Name: "Simple",
+						Name: "BillingInvoice",
 						PrimaryKeyFields: []*models.Field{
 							{ColumnName: "Id"},
 						},
+						PrimaryKeys: []*models.IndexKey{
+							{Field: &models.Field{ColumnName: "Id"}},
+						},
 						Fields: []*models.Field{
 							{
 								Name:            "ID",
@@ -437,7 +488,7 @@ func TestLoader_CustomTypes(t *testing.T) {
 							},
 							{
 								Name:            "Value",
-								Type:            "Value",
+								Type:            "string",
 								OriginalType:    "string",
 								NullValue:       `""`,
 								Len:             32,
@@ -447,31 +498,77 @@ func TestLoader_CustomTypes(t *testing.T) {
 								IsPrimaryKey:    false,
 							},
 						},
-						TableName: "Simple",
+						TableName: "billing.Invoices",
+						Schema:    "billing",
 						Indexes: []*models.Index{
 							{
-								Name:           "SimpleIndex",
-								FuncName:       "SimplesBySimpleIndex",
-								LegacyFuncName: "SimplesByValue",
+								Name:           "InvoicesByValue",
+								FuncName:       "BillingInvoicesByInvoicesByValue",
+								LegacyFuncName: "BillingInvoicesByValue",
 								Fields: []*models.Field{
 									{ColumnName: "Value"},
 								},
-								IndexName: "SimpleIndex",
+								Keys: []*models.IndexKey{
+									{Field: &models.Field{ColumnName: "Value"}},
+								},
+								IndexName: "billing.InvoicesByValue",
+								IndexType: models.IndexTypeIndex,
 							},
+						},
+					},
+				},
+				Dialect: models.DialectGoogleSQL,
+			},
+		},
+		{
+			name:   "DefaultSchemaAllowList",
+			opt:    Option{Schemas: []string{""}},
+			schema: namedSchema,
+			expectedSchema: &models.Schema{
+				Types: []*models.Type{
+					{
+						Name: "Invoice",
+						PrimaryKeyFields: []*models.Field{
+							{ColumnName: "Id"},
+						},
+						PrimaryKeys: []*models.IndexKey{
+							{Field: &models.Field{ColumnName: "Id"}},
+						},
+						Fields: []*models.Field{
 							{
-								Name:           "SimpleIndex2",
-								FuncName:       "SimpleBySimpleIndex2",
-								LegacyFuncName: "SimpleByIDValue",
-								Fields: []*models.Field{
-									{ColumnName: "Id"},
-									{ColumnName: "Value"},
-								},
-								IndexName: "SimpleIndex2",
-								IsUnique:  true,
+								Name:            "ID",
+								Type:            "int64",
+								OriginalType:    "int64",
+								NullValue:       "0",
+								Len:             -1,
+								ColumnName:      "Id",
+								SpannerDataType: "INT64",
+								IsNotNull:       true,
+								IsPrimaryKey:    true,
 							},
 						},
+						TableName: "Invoices",
 					},
 				},
+				Dialect: models.DialectGoogleSQL,
+			},
+		},
+		{
+			name:   "AlterTableAddFK",
+			opt:    Option{},
+			schema: alterTableAddFKSchema,
+			expectedSchema: &models.Schema{
+				Types:   []*models.Type{},
+				Dialect: models.DialectGoogleSQL,
+			},
+		},
+		{
+			name:   "AlterTableAddConstraintFK",
+			opt:    Option{},
+			schema: alterTableAddConstraintFKSchema,
+			expectedSchema: &models.Schema{
+				Types:   []*models.Type{},
+				Dialect: models.DialectGoogleSQL,
 			},
 		},
 	}
@@ -481,54 +578,2200 @@ func TestLoader_CustomTypes(t *testing.T) {
 			l := setUpTypeLoader(t, tc.schema, tc.opt)
 
 			schema, err := l.LoadSchema()
-
-			if tc.expectedErr != "" {
-				if err == nil {
-					t.Fatal("expected to load schema failure")
-				}
-
-				if err.Error() != tc.expectedErr {
-					t.Fatalf("unexpected error: expected: %s, actual: %s", tc.expectedErr, err.Error())
-				}
-			} else {
-				if err != nil {
-					t.Fatalf("failed to load schema: %v", err)
-				}
-
-				compareSchemas(t, schema, tc.expectedSchema)
+			if err != nil {
+				t.Fatalf("failed to load schema: %v", err)
 			}
+
+			compareSchemas(t, schema, tc.expectedSchema)
 		})
 	}
 }
 
-func Test_setIndexesToTables(t *testing.T) {
-	tests := []struct {
-		table  map[string]*models.Type
-		ix     map[string]*models.Index
-		result map[string]int
+func TestLoader_Interleave(t *testing.T) {
+	schema := `
+CREATE TABLE Parent (
+  Id INT64 NOT NULL,
+) PRIMARY KEY(Id);
+
+CREATE TABLE Child (
+  Id INT64 NOT NULL,
+  ChildId INT64 NOT NULL,
+) PRIMARY KEY(Id, ChildId),
+INTERLEAVE IN PARENT Parent ON DELETE CASCADE;
+
+CREATE TABLE GrandChild (
+  Id INT64 NOT NULL,
+  ChildId INT64 NOT NULL,
+  GrandChildId INT64 NOT NULL,
+) PRIMARY KEY(Id, ChildId, GrandChildId),
+INTERLEAVE IN Child;
+
+CREATE TABLE Sibling (
+  Id INT64 NOT NULL,
+  SiblingId INT64 NOT NULL,
+) PRIMARY KEY(Id, SiblingId),
+INTERLEAVE IN PARENT Parent;
+`
+
+	type interleave struct {
+		Parent         string
+		Children       []string
+		InterleaveType string
+		OnDeleteAction string
+	}
+
+	table := []struct {
+		name     string
+		opt      Option
+		expected map[string]interleave
 	}{
 		{
-			table: map[string]*models.Type{
-				"TableA": &models.Type{
-					Indexes: []*models.Index{},
-				},
-			},
-			ix: map[string]*models.Index{
-				"TableA_Index1": &models.Index{
-					Type: &models.Type{
-						TableName: "TableA",
-					},
-					IndexName: "Index1",
-				},
-				"TableA_Index2": &models.Index{
-					Type: &models.Type{
-						TableName: "TableA",
-					},
-					IndexName: "Index2",
-				},
-			},
-			result: map[string]int{
-				"TableA": 2,
+			name: "All",
+			opt:  Option{},
+			expected: map[string]interleave{
+				"Parent":     {Children: []string{"Child", "Sibling"}},
+				"Child":      {Parent: "Parent", Children: []string{"GrandChild"}, InterleaveType: "IN PARENT", OnDeleteAction: "CASCADE"},
+				"GrandChild": {Parent: "Child", InterleaveType: "IN"},
+				"Sibling":    {Parent: "Parent", InterleaveType: "IN PARENT", OnDeleteAction: "NO ACTION"},
+			},
+		},
+		{
+			name: "IgnoreParent",
+			opt:  Option{IgnoreTables: []string{"Parent"}},
+			expected: map[string]interleave{
+				"Child":      {Children: []string{"GrandChild"}, InterleaveType: "IN PARENT", OnDeleteAction: "CASCADE"},
+				"GrandChild": {Parent: "Child", InterleaveType: "IN"},
+				"Sibling":    {InterleaveType: "IN PARENT", OnDeleteAction: "NO ACTION"},
+			},
+		},
+	}
+
+	for _, tc := range table {
+		t.Run(tc.name, func(t *testing.T) {
+			l := setUpTypeLoader(t, schema, tc.opt)
+
+			schema, err := l.LoadSchema()
+			if err != nil {
+				t.Fatalf("failed to load schema: %v", err)
+			}
+
+			got := make(map[string]interleave)
+			for _, typ := range schema.Types {
+				var v interleave
+				if typ.Parent != nil {
+					v.Parent = typ.Parent.TableName
+				}
+				for _, c := range typ.Children {
+					v.Children = append(v.Children, c.TableName)
+				}
+				v.InterleaveType = typ.InterleaveType
+				v.OnDeleteAction = typ.OnDeleteAction
+				got[typ.TableName] = v
+			}
+
+			if diff := cmp.Diff(got, tc.expected); diff != "" {
+				t.Errorf("(-got, +want)\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestLoader_ForeignKeys(t *testing.T) {
+	schema := `
+CREATE TABLE Customers (
+  CustomerID INT64 NOT NULL,
+) PRIMARY KEY(CustomerID);
+
+CREATE TABLE Orders (
+  OrderID INT64 NOT NULL,
+  CustomerID INT64 NOT NULL,
+  CONSTRAINT FK_OrderCustomer FOREIGN KEY (CustomerID) REFERENCES Customers (CustomerID) ON DELETE CASCADE,
+) PRIMARY KEY(OrderID);
+
+CREATE TABLE Transfers (
+  TransferID INT64 NOT NULL,
+  FromID INT64 NOT NULL,
+  ToID INT64,
+) PRIMARY KEY(TransferID);
+
+ALTER TABLE Transfers ADD CONSTRAINT FK_TransferFrom FOREIGN KEY (FromID) REFERENCES Customers (CustomerID);
+ALTER TABLE Transfers ADD FOREIGN KEY (ToID) REFERENCES Customers (CustomerID);
+`
+
+	type foreignKey struct {
+		Name           string
+		ConstraintName string
+		Columns        []string
+		RefTable       string
+		RefColumns     []string
+		OnDeleteAction string
+	}
+
+	table := []struct {
+		name     string
+		opt      Option
+		expected map[string][]foreignKey
+	}{
+		{
+			name: "All",
+			opt:  Option{},
+			expected: map[string][]foreignKey{
+				"Customers": nil,
+				"Orders": {
+					{
+						Name:           "Customer",
+						ConstraintName: "FK_OrderCustomer",
+						Columns:        []string{"CustomerID"},
+						RefTable:       "Customers",
+						RefColumns:     []string{"CustomerID"},
+						OnDeleteAction: "CASCADE",
+					},
+				},
+				"Transfers": {
+					{
+						Name:           "CustomerByFromID",
+						ConstraintName: "FK_TransferFrom",
+						Columns:        []string{"FromID"},
+						RefTable:       "Customers",
+						RefColumns:     []string{"CustomerID"},
+						OnDeleteAction: "NO ACTION",
+					},
+					{
+						Name:           "CustomerByToID",
+						Columns:        []string{"ToID"},
+						RefTable:       "Customers",
+						RefColumns:     []string{"CustomerID"},
+						OnDeleteAction: "NO ACTION",
+					},
+				},
+			},
+		},
+		{
+			name: "IgnoreReferencedTable",
+			opt:  Option{IgnoreTables: []string{"Customers"}},
+			expected: map[string][]foreignKey{
+				"Orders":    nil,
+				"Transfers": nil,
+			},
+		},
+		{
+			name: "IgnoreReferencingField",
+			opt:  Option{IgnoreFields: []string{"FromID"}},
+			expected: map[string][]foreignKey{
+				"Customers": nil,
+				"Orders": {
+					{
+						Name:           "Customer",
+						ConstraintName: "FK_OrderCustomer",
+						Columns:        []string{"CustomerID"},
+						RefTable:       "Customers",
+						RefColumns:     []string{"CustomerID"},
+						OnDeleteAction: "CASCADE",
+					},
+				},
+				"Transfers": {
+					{
+						Name:           "Customer",
+						Columns:        []string{"ToID"},
+						RefTable:       "Customers",
+						RefColumns:     []string{"CustomerID"},
+						OnDeleteAction: "NO ACTION",
+					},
+				},
+			},
+		},
+	}
+
+	for _, tc := range table {
+		t.Run(tc.name, func(t *testing.T) {
+			l := setUpTypeLoader(t, schema, tc.opt)
+
+			schema, err := l.LoadSchema()
+			if err != nil {
+				t.Fatalf("failed to load schema: %v", err)
+			}
+
+			got := make(map[string][]foreignKey)
+			for _, typ := range schema.Types {
+				var fks []foreignKey
+				for _, fk := range typ.ForeignKeys {
+					v := foreignKey{
+						Name:           fk.Name,
+						ConstraintName: fk.ConstraintName,
+						RefTable:       fk.RefType.TableName,
+						OnDeleteAction: fk.OnDeleteAction,
+					}
+					for _, f := range fk.Fields {
+						v.Columns = append(v.Columns, f.ColumnName)
+					}
+					for _, f := range fk.RefFields {
+						v.RefColumns = append(v.RefColumns, f.ColumnName)
+					}
+					fks = append(fks, v)
+				}
+				got[typ.TableName] = fks
+			}
+
+			if diff := cmp.Diff(got, tc.expected); diff != "" {
+				t.Errorf("(-got, +want)\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestLoader_View(t *testing.T) {
+	tables := `
+CREATE TABLE Customers (
+  Id INT64 NOT NULL,
+  Name STRING(32) NOT NULL,
+  Secret STRING(32) HIDDEN,
+) PRIMARY KEY(Id);
+
+CREATE TABLE Orders (
+  OrderId INT64 NOT NULL,
+  CustomerId INT64 NOT NULL,
+  Total NUMERIC,
+) PRIMARY KEY(OrderId);
+`
+
+	table := []struct {
+		name     string
+		schema   string
+		expected map[string][]string
+		err      string
+	}{
+		{
+			name: "Star",
+			schema: tables + `
+CREATE VIEW AllCustomers SQL SECURITY INVOKER AS SELECT * FROM Customers;
+`,
+			expected: map[string][]string{
+				"AllCustomers": {"Id INT64", "Name STRING(32)"},
+			},
+		},
+		{
+			name: "Join",
+			schema: tables + `
+CREATE VIEW CustomerOrders SQL SECURITY INVOKER AS
+SELECT c.Id, c.Name AS CustomerName, o.* EXCEPT (CustomerId), CAST(o.Total AS FLOAT64) AS TotalFloat, "x" AS Tag
+FROM Customers AS c JOIN Orders AS o ON c.Id = o.CustomerId;
+`,
+			expected: map[string][]string{
+				"CustomerOrders": {
+					"Id INT64",
+					"CustomerName STRING(32)",
+					"OrderId INT64",
+					"Total NUMERIC",
+					"TotalFloat FLOAT64",
+					"Tag STRING(MAX)",
+				},
+			},
+		},
+		{
+			name: "NestedView",
+			schema: tables + `
+CREATE VIEW CustomerNames SQL SECURITY INVOKER AS SELECT Id, Name FROM Customers;
+CREATE VIEW Names SQL SECURITY INVOKER AS SELECT n.Name FROM CustomerNames AS n ORDER BY n.Name;
+`,
+			expected: map[string][]string{
+				"CustomerNames": {"Id INT64", "Name STRING(32)"},
+				"Names":         {"Name STRING(32)"},
+			},
+		},
+		{
+			name: "UndeterminedType",
+			schema: tables + `
+CREATE VIEW Invalid SQL SECURITY INVOKER AS SELECT Id + 1 AS Id FROM Customers;
+`,
+			err: "schema.sql:14:1: view Invalid: type of Id + 1 is not determined, use CAST",
+		},
+		{
+			name: "UnknownTable",
+			schema: tables + `
+CREATE VIEW Invalid SQL SECURITY INVOKER AS SELECT Id FROM Unknown;
+`,
+			err: "schema.sql:14:1: view Invalid: unknown table Unknown",
+		},
+	}
+
+	for _, tc := range table {
+		t.Run(tc.name, func(t *testing.T) {
+			l := setUpTypeLoader(t, tc.schema, Option{})
+
+			schema, err := l.LoadSchema()
+			if tc.err != "" {
+				if err == nil || errorMessage(err) != tc.err {
+					t.Fatalf("expect error %q, but got %v", tc.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("failed to load schema: %v", err)
+			}
+
+			got := make(map[string][]string)
+			for _, typ := range schema.Types {
+				if !typ.IsView {
+					continue
+				}
+				if len(typ.PrimaryKeyFields) != 0 {
+					t.Errorf("view %s must not have primary key fields", typ.TableName)
+				}
+
+				var cols []string
+				for _, f := range typ.Fields {
+					if f.IsNotNull {
+						t.Errorf("column %s of view %s must be nullable", f.ColumnName, typ.TableName)
+					}
+					cols = append(cols, f.ColumnName+" "+f.SpannerDataType)
+				}
+				got[typ.TableName] = cols
+			}
+
+			if diff := cmp.Diff(got, tc.expected); diff != "" {
+				t.Errorf("(-got, +want)\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestLoader_Defaults(t *testing.T) {
+	schema := `
+CREATE TABLE Items (
+  Id INT64 NOT NULL,
+  Name STRING(32) NOT NULL DEFAULT ("unknown"),
+  Quantity INT64 DEFAULT (1 + 2),
+  CreatedAt TIMESTAMP NOT NULL DEFAULT (CURRENT_TIMESTAMP()),
+  Price INT64 AS (Quantity * 100) STORED,
+) PRIMARY KEY(Id);
+`
+
+	l := setUpTypeLoader(t, schema, Option{})
+	s, err := l.LoadSchema()
+	if err != nil {
+		t.Fatalf("failed to load schema: %v", err)
+	}
+
+	type field struct {
+		HasDefault  bool
+		DefaultExpr string
+	}
+	got := make(map[string]field)
+	for _, f := range s.Types[0].Fields {
+		got[f.ColumnName] = field{HasDefault: f.HasDefault, DefaultExpr: f.DefaultExpr}
+	}
+
+	expected := map[string]field{
+		"Id":        {},
+		"Name":      {HasDefault: true, DefaultExpr: `"unknown"`},
+		"Quantity":  {HasDefault: true, DefaultExpr: "1 + 2"},
+		"CreatedAt": {HasDefault: true, DefaultExpr: "CURRENT_TIMESTAMP()"},
+		"Price":     {},
+	}
+	if diff := cmp.Diff(got, expected); diff != "" {
+		t.Errorf("(-got, +want)\n%s", diff)
+	}
+}
+
+func TestLoader_CommitTimestamps(t *testing.T) {
+	schema := `
+CREATE TABLE Items (
+  Id INT64 NOT NULL,
+  CreatedAt TIMESTAMP NOT NULL OPTIONS (allow_commit_timestamp = true),
+  UpdatedAt TIMESTAMP OPTIONS (allow_commit_timestamp = true),
+  DeletedAt TIMESTAMP OPTIONS (allow_commit_timestamp = false),
+) PRIMARY KEY(Id);
+
+CREATE TABLE Events (
+  CreatedAt TIMESTAMP NOT NULL OPTIONS (allow_commit_timestamp = true),
+  Id INT64 NOT NULL,
+) PRIMARY KEY(CreatedAt, Id);
+`
+
+	type field struct {
+		Allow bool
+		Use   bool
+	}
+
+	table := []struct {
+		name            string
+		commitTimestamp bool
+		columns         map[string][]config.Column
+		expected        map[string]map[string]field
+		expectedErr     string
+	}{
+		{
+			name: "Default",
+			expected: map[string]map[string]field{
+				"Items": {
+					"Id":        {},
+					"CreatedAt": {Allow: true},
+					"UpdatedAt": {Allow: true},
+					"DeletedAt": {},
+				},
+				"Events": {
+					"CreatedAt": {Allow: true},
+					"Id":        {},
+				},
+			},
+		},
+		{
+			name:            "Enabled",
+			commitTimestamp: true,
+			expected: map[string]map[string]field{
+				"Items": {
+					"Id":        {},
+					"CreatedAt": {Allow: true, Use: true},
+					"UpdatedAt": {Allow: true, Use: true},
+					"DeletedAt": {},
+				},
+				"Events": {
+					"CreatedAt": {Allow: true},
+					"Id":        {},
+				},
+			},
+		},
+		{
+			name: "Column",
+			columns: map[string][]config.Column{
+				"Items": {{Name: "UpdatedAt", CommitTimestamp: boolPtr(true)}},
+			},
+			expected: map[string]map[string]field{
+				"Items": {
+					"Id":        {},
+					"CreatedAt": {Allow: true},
+					"UpdatedAt": {Allow: true, Use: true},
+					"DeletedAt": {},
+				},
+				"Events": {
+					"CreatedAt": {Allow: true},
+					"Id":        {},
+				},
+			},
+		},
+		{
+			name:            "DisabledColumn",
+			commitTimestamp: true,
+			columns: map[string][]config.Column{
+				"Items": {{Name: "CreatedAt", CommitTimestamp: boolPtr(false)}},
+			},
+			expected: map[string]map[string]field{
+				"Items": {
+					"Id":        {},
+					"CreatedAt": {Allow: true},
+					"UpdatedAt": {Allow: true, Use: true},
+					"DeletedAt": {},
+				},
+				"Events": {
+					"CreatedAt": {Allow: true},
+					"Id":        {},
+				},
+			},
+		},
+		{
+			name: "NotAllowed",
+			columns: map[string][]config.Column{
+				"Items": {{Name: "DeletedAt", CommitTimestamp: boolPtr(true)}},
+			},
+			expectedErr: "schema.sql:6:3: column DeletedAt in the table Items does not allow commit timestamp",
+		},
+		{
+			name: "PrimaryKey",
+			columns: map[string][]config.Column{
+				"Events": {{Name: "CreatedAt", CommitTimestamp: boolPtr(true)}},
+			},
+			expectedErr: "schema.sql:10:3: commit timestamp cannot be written by mutations into primary key column CreatedAt in the table Events",
+		},
+		{
+			name: "UnknownColumn",
+			columns: map[string][]config.Column{
+				"Items": {{Name: "Unknown", CommitTimestamp: boolPtr(true)}},
+			},
+			expectedErr: "schema.sql:2:1: unknown commit timestamp column Unknown in the table Items",
+		},
+	}
+
+	for _, tc := range table {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := &config.Config{CommitTimestamp: tc.commitTimestamp}
+			for name, cols := range tc.columns {
+				cfg.Tables = append(cfg.Tables, config.Table{Name: name, Columns: cols})
+			}
+
+			l := setUpTypeLoader(t, schema, Option{Config: cfg})
+			s, err := l.LoadSchema()
+			if tc.expectedErr != "" {
+				if err == nil || errorMessage(err) != tc.expectedErr {
+					t.Fatalf("expect error %q, but got %v", tc.expectedErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("failed to load schema: %v", err)
+			}
+
+			got := make(map[string]map[string]field)
+			for _, typ := range s.Types {
+				fields := make(map[string]field)
+				for _, f := range typ.Fields {
+					fields[f.ColumnName] = field{Allow: f.AllowCommitTimestamp, Use: f.UseCommitTimestamp}
+				}
+				got[typ.TableName] = fields
+			}
+
+			if diff := cmp.Diff(got, tc.expected); diff != "" {
+				t.Errorf("(-got, +want)\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestLoader_CheckConstraints(t *testing.T) {
+	schema := `
+CREATE TABLE Items (
+  Id INT64 NOT NULL,
+  Price INT64 NOT NULL,
+  Status STRING(16),
+  CONSTRAINT CK_Price CHECK (Price > 0),
+  CHECK (Status IN ('A', 'B')),
+) PRIMARY KEY(Id);
+
+CREATE VIEW ItemPrices SQL SECURITY INVOKER AS SELECT Id, Price FROM Items;
+
+ALTER TABLE Items ADD CONSTRAINT CK_Status CHECK (REGEXP_CONTAINS(Status, r'^[A-Z]+$'));
+`
+
+	l := setUpTypeLoader(t, schema, Option{})
+	s, err := l.LoadSchema()
+	if err != nil {
+		t.Fatalf("failed to load schema: %v", err)
+	}
+
+	got := make(map[string][]*models.CheckConstraint)
+	for _, typ := range s.Types {
+		got[typ.TableName] = typ.CheckConstraints
+	}
+
+	expected := map[string][]*models.CheckConstraint{
+		"Items": {
+			{Name: "CK_Price", Expr: "Price > 0"},
+			{Name: "", Expr: `Status IN ("A", "B")`},
+			{Name: "CK_Status", Expr: `REGEXP_CONTAINS(Status, "^[A-Z]+$")`},
+		},
+		"ItemPrices": nil,
+	}
+	if diff := cmp.Diff(got, expected); diff != "" {
+		t.Errorf("(-got, +want)\n%s", diff)
+	}
+}
+
+func TestLoader_RowDeletionPolicy(t *testing.T) {
+	schema := `
+CREATE TABLE Sessions (
+  Id INT64 NOT NULL,
+  ExpiredAt TIMESTAMP NOT NULL,
+) PRIMARY KEY(Id), ROW DELETION POLICY (OLDER_THAN(ExpiredAt, INTERVAL 30 DAY));
+
+CREATE TABLE Events (
+  Id INT64 NOT NULL,
+  CreatedAt TIMESTAMP,
+) PRIMARY KEY(Id);
+
+CREATE TABLE Logs (
+  Id INT64 NOT NULL,
+  CreatedAt TIMESTAMP,
+) PRIMARY KEY(Id), ROW DELETION POLICY (OLDER_THAN(CreatedAt, INTERVAL 1 DAY));
+
+ALTER TABLE Events ADD ROW DELETION POLICY (OLDER_THAN(CreatedAt, INTERVAL 7 DAY));
+ALTER TABLE Sessions REPLACE ROW DELETION POLICY (OLDER_THAN(ExpiredAt, INTERVAL 90 DAY));
+ALTER TABLE Logs DROP ROW DELETION POLICY;
+`
+
+	l := setUpTypeLoader(t, schema, Option{})
+	s, err := l.LoadSchema()
+	if err != nil {
+		t.Fatalf("failed to load schema: %v", err)
+	}
+
+	type policy struct {
+		Column  string
+		NumDays int64
+	}
+	got := make(map[string]*policy)
+	for _, typ := range s.Types {
+		if p := typ.RowDeletionPolicy; p != nil {
+			got[typ.TableName] = &policy{Column: p.Field.ColumnName, NumDays: p.NumDays}
+		} else {
+			got[typ.TableName] = nil
+		}
+	}
+
+	expected := map[string]*policy{
+		"Sessions": {Column: "ExpiredAt", NumDays: 90},
+		"Events":   {Column: "CreatedAt", NumDays: 7},
+		"Logs":     nil,
+	}
+	if diff := cmp.Diff(got, expected); diff != "" {
+		t.Errorf("(-got, +want)\n%s", diff)
+	}
+}
+
+func TestLoader_RowDeletionPolicy_Errors(t *testing.T) {
+	schema := `
+CREATE TABLE Sessions (
+  Id INT64 NOT NULL,
+  ExpiredAt DATE NOT NULL,
+) PRIMARY KEY(Id), ROW DELETION POLICY (OLDER_THAN(ExpiredAt, INTERVAL 30 DAY));
+`
+
+	l := setUpTypeLoader(t, schema, Option{})
+	_, err := l.LoadSchema()
+	if err == nil {
+		t.Fatal("expected an error, but got nil")
+	}
+
+	expected := "schema.sql:4:3: row deletion policy column ExpiredAt in the table Sessions must be TIMESTAMP"
+	if msg := errorMessage(err); msg != expected {
+		t.Errorf("expected error %q, but got %q", expected, msg)
+	}
+}
+
+func Test_parseRowDeletionPolicy(t *testing.T) {
+	table := []struct {
+		expr    string
+		column  string
+		numDays int64
+		err     bool
+	}{
+		{expr: "OLDER_THAN(ExpiredAt, INTERVAL 30 DAY)", column: "ExpiredAt", numDays: 30},
+		{expr: "older_than(`ExpiredAt`,INTERVAL 1 DAY)", column: "ExpiredAt", numDays: 1},
+		{expr: "INTERVAL '30 DAYS' ON expired_at", column: "expired_at", numDays: 30},
+		{expr: `INTERVAL '1 DAY' ON "ExpiredAt"`, column: "ExpiredAt", numDays: 1},
+		{expr: "OLDER_THAN(ExpiredAt, INTERVAL 30 HOUR)", err: true},
+	}
+
+	for _, tc := range table {
+		t.Run(tc.expr, func(t *testing.T) {
+			column, numDays, err := parseRowDeletionPolicy(tc.expr)
+			if tc.err {
+				if err == nil {
+					t.Fatal("expected an error, but got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if column != tc.column || numDays != tc.numDays {
+				t.Errorf("expect (%s, %d), but got (%s, %d)", tc.column, tc.numDays, column, numDays)
+			}
+		})
+	}
+}
+
+func TestLoader_IndexMetadata(t *testing.T) {
+	schema := `
+CREATE TABLE Singers (
+  SingerId INT64 NOT NULL,
+  Name STRING(MAX),
+) PRIMARY KEY(SingerId);
+
+CREATE TABLE Albums (
+  SingerId INT64 NOT NULL,
+  AlbumId INT64 NOT NULL,
+  Title STRING(MAX),
+  ReleasedAt TIMESTAMP,
+  Title_Tokens TOKENLIST AS (TOKENIZE_FULLTEXT(Title)) HIDDEN,
+  Embedding ARRAY<FLOAT64>(vector_length=>3),
+) PRIMARY KEY(SingerId, AlbumId),
+  INTERLEAVE IN PARENT Singers ON DELETE CASCADE;
+
+CREATE NULL_FILTERED INDEX AlbumsByTitle ON Albums(Title);
+CREATE INDEX AlbumsBySingerReleasedAt ON Albums(SingerId, ReleasedAt DESC), INTERLEAVE IN Singers;
+CREATE SEARCH INDEX AlbumsIndex ON Albums(Title_Tokens);
+CREATE VECTOR INDEX AlbumsByEmbedding ON Albums(Embedding) WHERE Embedding IS NOT NULL OPTIONS (distance_type = 'COSINE');
+`
+
+	l := setUpTypeLoader(t, schema, Option{})
+	s, err := l.LoadSchema()
+	if err != nil {
+		t.Fatalf("failed to load schema: %v", err)
+	}
+
+	type key struct {
+		Column string
+		Desc   bool
+	}
+	type index struct {
+		NullFiltered  bool
+		InterleavedIn string
+		IndexType     string
+		Keys          []key
+	}
+	got := make(map[string]index)
+	for _, typ := range s.Types {
+		for _, ix := range typ.Indexes {
+			v := index{NullFiltered: ix.NullFiltered, InterleavedIn: ix.InterleavedIn, IndexType: ix.IndexType}
+			for _, k := range ix.Keys {
+				v.Keys = append(v.Keys, key{Column: k.Field.ColumnName, Desc: k.Desc})
+			}
+			got[ix.IndexName] = v
+		}
+	}
+
+	expected := map[string]index{
+		"AlbumsByTitle": {
+			NullFiltered: true,
+			IndexType:    models.IndexTypeIndex,
+			Keys:         []key{{Column: "Title"}},
+		},
+		"AlbumsBySingerReleasedAt": {
+			InterleavedIn: "Singers",
+			IndexType:     models.IndexTypeIndex,
+			Keys:          []key{{Column: "SingerId"}, {Column: "ReleasedAt", Desc: true}},
+		},
+		"AlbumsIndex": {
+			IndexType: models.IndexTypeSearch,
+			Keys:      []key{{Column: "Title_Tokens"}},
+		},
+		"AlbumsByEmbedding": {
+			IndexType: models.IndexTypeVector,
+			Keys:      []key{{Column: "Embedding"}},
+		},
+	}
+	if diff := cmp.Diff(got, expected); diff != "" {
+		t.Errorf("(-got, +want)\n%s", diff)
+	}
+}
+
+func TestLoader_Migrations(t *testing.T) {
+	migrations := map[string]string{
+		"000001_init.sql": `
+CREATE TABLE Singers (
+  SingerId INT64 NOT NULL,
+  Name STRING(64),
+  Age INT64,
+) PRIMARY KEY(SingerId);
+
+CREATE TABLE Albums (
+  SingerId INT64 NOT NULL,
+  AlbumId INT64 NOT NULL,
+  Title STRING(MAX),
+) PRIMARY KEY(SingerId, AlbumId),
+  INTERLEAVE IN PARENT Singers ON DELETE CASCADE;
+
+CREATE TABLE Tmp (
+  Id INT64 NOT NULL,
+) PRIMARY KEY(Id);
+
+CREATE INDEX SingersByName ON Singers(Name);
+CREATE INDEX SingersByAge ON Singers(Age);
+`,
+		"000002_alter.sql": `
+ALTER TABLE Singers ADD COLUMN Country STRING(2) NOT NULL DEFAULT ("JP");
+ALTER TABLE Singers ALTER COLUMN Name STRING(128) NOT NULL;
+ALTER TABLE Albums ADD COLUMN UpdatedAt TIMESTAMP OPTIONS (allow_commit_timestamp = true);
+ALTER TABLE Albums SET ON DELETE NO ACTION;
+ALTER INDEX SingersByName ADD STORED COLUMN Country;
+ALTER TABLE Tmp RENAME TO Temp;
+`,
+		"000003_drop.sql": `
+DROP INDEX SingersByAge;
+ALTER TABLE Singers DROP COLUMN Age;
+ALTER TABLE Albums ALTER COLUMN Title SET DEFAULT ("untitled");
+DROP TABLE Temp;
+`,
+		"README.md": "not a migration",
+	}
+
+	dir := t.TempDir()
+	for name, ddl := range migrations {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(ddl), 0o644); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+
+	for name, path := range map[string]string{
+		"Directory": dir,
+		"Glob":      filepath.Join(dir, "*.sql"),
+	} {
+		t.Run(name, func(t *testing.T) {
+			source, err := NewSchemaParserSource(path)
+			if err != nil {
+				t.Fatalf("failed to create schema parser source: %v", err)
+			}
+			inflector, err := internal.NewInflector(nil)
+			if err != nil {
+				t.Fatalf("failed to create inflector: %v", err)
+			}
+
+			s, err := NewTypeLoader(source, inflector, Option{}).LoadSchema()
+			if err != nil {
+				t.Fatalf("failed to load schema: %v", err)
+			}
+
+			type field struct {
+				Name       string
+				Type       string
+				NotNull    bool
+				Default    string
+				CommitTime bool
+			}
+			type index struct {
+				Name    string
+				Storing []string
+			}
+			type typ struct {
+				OnDelete string
+				Fields   []field
+				Indexes  []index
+			}
+
+			got := make(map[string]typ)
+			for _, tt := range s.Types {
+				v := typ{OnDelete: tt.OnDeleteAction}
+				for _, f := range tt.Fields {
+					v.Fields = append(v.Fields, field{
+						Name:       f.ColumnName,
+						Type:       f.SpannerDataType,
+						NotNull:    f.IsNotNull,
+						Default:    f.DefaultExpr,
+						CommitTime: f.AllowCommitTimestamp,
+					})
+				}
+				for _, ix := range tt.Indexes {
+					var storing []string
+					for _, f := range ix.StoringFields {
+						storing = append(storing, f.ColumnName)
+					}
+					v.Indexes = append(v.Indexes, index{Name: ix.IndexName, Storing: storing})
+				}
+				got[tt.TableName] = v
+			}
+
+			expected := map[string]typ{
+				"Singers": {
+					Fields: []field{
+						{Name: "SingerId", Type: "INT64", NotNull: true},
+						{Name: "Name", Type: "STRING(128)", NotNull: true},
+						{Name: "Country", Type: "STRING(2)", NotNull: true, Default: `"JP"`},
+					},
+					Indexes: []index{
+						{Name: "SingersByName", Storing: []string{"Country"}},
+					},
+				},
+				"Albums": {
+					OnDelete: "NO ACTION",
+					Fields: []field{
+						{Name: "SingerId", Type: "INT64", NotNull: true},
+						{Name: "AlbumId", Type: "INT64", NotNull: true},
+						{Name: "Title", Type: "STRING(MAX)", Default: `"untitled"`},
+						{Name: "UpdatedAt", Type: "TIMESTAMP", CommitTime: true},
+					},
+				},
+			}
+			if diff := cmp.Diff(got, expected); diff != "" {
+				t.Errorf("(-got, +want)\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestNewSchemaParserSource_Semicolons(t *testing.T) {
+	schema := `
+-- a comment; with a semicolon
+CREATE TABLE Items (
+  Id INT64 NOT NULL,
+  Name STRING(MAX) NOT NULL DEFAULT ("a;b"), /* another; comment */
+) PRIMARY KEY(Id);
+
+CREATE INDEX ItemsByName ON Items(Name);
+`
+
+	l := setUpTypeLoader(t, schema, Option{})
+	s, err := l.LoadSchema()
+	if err != nil {
+		t.Fatalf("failed to load schema: %v", err)
+	}
+
+	if len(s.Types) != 1 || len(s.Types[0].Fields) != 2 || len(s.Types[0].Indexes) != 1 {
+		t.Fatalf("unexpected schema: %+v", s.Types)
+	}
+	if got, want := s.Types[0].Fields[1].DefaultExpr, `"a;b"`; got != want {
+		t.Errorf("expect default %s but got %s", want, got)
+	}
+}
+
+func TestNewSchemaParserSource_IgnoredStatements(t *testing.T) {
+	schema := `
+CREATE SCHEMA sch;
+DROP SCHEMA sch;
+ALTER DATABASE db SET OPTIONS (optimizer_version = 5);
+CREATE PROTO BUNDLE (examples.music.Singer);
+CREATE SEQUENCE Seq OPTIONS (sequence_kind = "bit_reversed_positive");
+CREATE ROLE Reader;
+GRANT SELECT ON TABLE Items TO ROLE Reader;
+
+CREATE TABLE Items (
+  Id INT64 NOT NULL DEFAULT (GET_NEXT_SEQUENCE_VALUE(SEQUENCE Seq)),
+) PRIMARY KEY(Id);
+
+REVOKE SELECT ON TABLE Items FROM ROLE Reader;
+DROP ROLE Reader;
+`
+
+	l := setUpTypeLoader(t, schema, Option{})
+	s, err := l.LoadSchema()
+	if err != nil {
+		t.Fatalf("failed to load schema: %v", err)
+	}
+
+	if len(s.Types) != 1 || s.Types[0].TableName != "Items" {
+		t.Fatalf("unexpected schema: %+v", s.Types)
+	}
+}
+
+func TestNewSchemaParserSource_Redefinitions(t *testing.T) {
+	schema := `
+CREATE TABLE Items (
+  Id INT64 NOT NULL,
+) PRIMARY KEY(Id);
+
+CREATE TABLE IF NOT EXISTS Items (
+  Id INT64 NOT NULL,
+  Name STRING(MAX),
+) PRIMARY KEY(Id);
+
+CREATE VIEW ItemIds SQL SECURITY INVOKER AS SELECT Items.Id FROM Items;
+CREATE OR REPLACE VIEW ItemIds SQL SECURITY INVOKER AS SELECT Items.Id, Items.Id AS Id2 FROM Items;
+`
+
+	l := setUpTypeLoader(t, schema, Option{})
+	s, err := l.LoadSchema()
+	if err != nil {
+		t.Fatalf("failed to load schema: %v", err)
+	}
+
+	if len(s.Types) != 2 {
+		t.Fatalf("unexpected schema: %+v", s.Types)
+	}
+	for _, typ := range s.Types {
+		switch typ.TableName {
+		case "Items":
+			// IF NOT EXISTS keeps the existing table
+			if len(typ.Fields) != 1 {
+				t.Errorf("expect the first definition of Items but got %d fields", len(typ.Fields))
+			}
+		case "ItemIds":
+			// OR REPLACE replaces the existing view
+			if len(typ.Fields) != 2 {
+				t.Errorf("expect the second definition of ItemIds but got %d fields", len(typ.Fields))
+			}
+		default:
+			t.Errorf("unexpected type %s", typ.TableName)
+		}
+	}
+}
+
+func TestNewSchemaParserSource_Errors(t *testing.T) {
+	tests := map[string]struct {
+		schema string
+		want   string
+	}{
+		"SyntaxError": {
+			schema: "CREATE TABLE T (\n  Id INT64 NOT NULL,\n  Name STRING(MAX) DEFAULT,\n) PRIMARY KEY(Id)",
+			want:   "schema.sql:3:27: ",
+		},
+		"AlterUnknownTable": {
+			schema: "\nALTER TABLE Unknown ADD COLUMN Name STRING(MAX)",
+			want:   "schema.sql:2:1: table Unknown is not found",
+		},
+		"DropUnknownColumn": {
+			schema: "CREATE TABLE T (Id INT64) PRIMARY KEY(Id);\n\nALTER TABLE T DROP COLUMN Name",
+			want:   "schema.sql:3:1: column Name is not found in the table T",
+		},
+		"DropUnknownIndex": {
+			schema: "DROP INDEX Unknown",
+			want:   "schema.sql:1:1: index Unknown is not found",
+		},
+		"DropUnknownTable": {
+			schema: "DROP TABLE Unknown",
+			want:   "schema.sql:1:1: table Unknown is not found",
+		},
+		"DuplicateTable": {
+			schema: "CREATE TABLE T (Id INT64) PRIMARY KEY(Id);\nCREATE TABLE T (Id INT64) PRIMARY KEY(Id)",
+			want:   "schema.sql:2:1: table T already exists",
+		},
+		"DuplicateView": {
+			schema: "CREATE TABLE T (Id INT64) PRIMARY KEY(Id);\nCREATE VIEW V SQL SECURITY INVOKER AS SELECT T.Id FROM T;\nCREATE VIEW V SQL SECURITY INVOKER AS SELECT T.Id FROM T",
+			want:   "schema.sql:3:1: view V already exists",
+		},
+		"IndexOnUndefinedTable": {
+			schema: "CREATE TABLE T (Id INT64) PRIMARY KEY(Id);\n  CREATE INDEX UnknownById ON Unknown(Id)",
+			want:   "schema.sql:2:3: index UnknownById is on an undefined table Unknown",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "schema.sql")
+			if err := os.WriteFile(path, []byte(tt.schema), 0o644); err != nil {
+				t.Fatalf("failed to write schema: %v", err)
+			}
+
+			_, err := NewSchemaParserSource(path)
+			if err == nil {
+				t.Fatalf("expect an error but got nil")
+			}
+			if !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("expect error to contain %q but got %q", tt.want, err.Error())
+			}
+		})
+	}
+}
+
+func TestLoader_ProtoTypes(t *testing.T) {
+	schema := `
+CREATE PROTO BUNDLE (examples.music.Singer, examples.music.Genre);
+
+CREATE TABLE Singers (
+  Id INT64 NOT NULL,
+  Info examples.music.Singer,
+  Genre examples.music.Genre,
+  MainGenre examples.music.Genre NOT NULL,
+  Albums ARRAY<examples.music.Singer>,
+  Genres ARRAY<examples.music.Genre> NOT NULL,
+) PRIMARY KEY(Id);
+`
+
+	protoTypes := []config.ProtoType{
+		{Name: "examples.music.Singer", GoType: "musicpb.Singer", ImportPath: "example.com/music/musicpb"},
+		{Name: "examples.music.Genre", GoType: "musicpb.Genre", ImportPath: "example.com/music/musicpb", Enum: true},
+	}
+
+	type field struct {
+		Type            string
+		NullValue       string
+		SpannerDataType string
+		ProtoKind       string
+		ProtoName       string
+		ImportPath      string
+	}
+
+	table := []struct {
+		name        string
+		protoTypes  []config.ProtoType
+		expected    map[string]field
+		expectedErr string
+	}{
+		{
+			name:       "Default",
+			protoTypes: protoTypes,
+			expected: map[string]field{
+				"Id": {Type: "int64", NullValue: "0", SpannerDataType: "INT64"},
+				"Info": {
+					Type: "*musicpb.Singer", NullValue: "nil", SpannerDataType: "PROTO<examples.music.Singer>",
+					ProtoKind: "PROTO", ProtoName: "examples.music.Singer", ImportPath: "example.com/music/musicpb",
+				},
+				"Genre": {
+					Type: "*musicpb.Genre", NullValue: "nil", SpannerDataType: "ENUM<examples.music.Genre>",
+					ProtoKind: "ENUM", ProtoName: "examples.music.Genre", ImportPath: "example.com/music/musicpb",
+				},
+				"MainGenre": {
+					Type: "musicpb.Genre", NullValue: "musicpb.Genre(0)", SpannerDataType: "ENUM<examples.music.Genre>",
+					ProtoKind: "ENUM", ProtoName: "examples.music.Genre", ImportPath: "example.com/music/musicpb",
+				},
+				"Albums": {
+					Type: "[]*musicpb.Singer", NullValue: "nil", SpannerDataType: "ARRAY<PROTO<examples.music.Singer>>",
+					ProtoKind: "PROTO", ProtoName: "examples.music.Singer", ImportPath: "example.com/music/musicpb",
+				},
+				"Genres": {
+					Type: "[]musicpb.Genre", NullValue: "[]musicpb.Genre{}", SpannerDataType: "ARRAY<ENUM<examples.music.Genre>>",
+					ProtoKind: "ENUM", ProtoName: "examples.music.Genre", ImportPath: "example.com/music/musicpb",
+				},
+			},
+		},
+		{
+			name: "UnqualifiedGoType",
+			protoTypes: []config.ProtoType{
+				{Name: "examples.music.Singer", GoType: "Singer", ImportPath: "example.com/music/musicpb"},
+			},
+			expectedErr: `schema.sql:6:3: column Info in the table Singers: goType of proto type examples.music.Singer must be qualified by the package name: "Singer"`,
+		},
+		{
+			name: "NoImportPath",
+			protoTypes: []config.ProtoType{
+				{Name: "examples.music.Singer", GoType: "musicpb.Singer"},
+			},
+			expectedErr: "schema.sql:6:3: column Info in the table Singers: importPath of proto type examples.music.Singer is required",
+		},
+	}
+
+	for _, tc := range table {
+		t.Run(tc.name, func(t *testing.T) {
+			l := setUpTypeLoader(t, schema, Option{Config: &config.Config{ProtoTypes: tc.protoTypes}})
+			s, err := l.LoadSchema()
+			if tc.expectedErr != "" {
+				if err == nil || errorMessage(err) != tc.expectedErr {
+					t.Fatalf("expect error %q, but got %v", tc.expectedErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("failed to load schema: %v", err)
+			}
+
+			got := make(map[string]field)
+			for _, f := range s.Types[0].Fields {
+				got[f.ColumnName] = field{
+					Type:            f.Type,
+					NullValue:       f.NullValue,
+					SpannerDataType: f.SpannerDataType,
+					ProtoKind:       f.ProtoKind,
+					ProtoName:       f.ProtoName,
+					ImportPath:      f.ImportPath,
+				}
+			}
+
+			if diff := cmp.Diff(got, tc.expected); diff != "" {
+				t.Errorf("(-got, +want)\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestTypeLoader_resolveProtoType(t *testing.T) {
+	l := NewTypeLoader(nil, nil, Option{Config: &config.Config{
+		ProtoTypes: []config.ProtoType{
+			{Name: "examples.music.Singer", GoType: "musicpb.Singer", ImportPath: "example.com/music/musicpb"},
+			{Name: "examples.music.Genre", GoType: "musicpb.Genre", ImportPath: "example.com/music/musicpb", Enum: true},
+		},
+	}})
+
+	table := []struct {
+		dataType    string
+		expected    *protoColumnType
+		expectedErr string
+	}{
+		{
+			dataType: "STRING(MAX)",
+		},
+		{
+			dataType: "PROTO<examples.music.Singer>",
+			expected: &protoColumnType{
+				DataType: "PROTO<examples.music.Singer>", Kind: "PROTO", Name: "examples.music.Singer",
+				GoType: "musicpb.Singer", ImportPath: "example.com/music/musicpb",
+			},
+		},
+		{
+			dataType: "ARRAY<ENUM<examples.music.Genre>>",
+			expected: &protoColumnType{
+				DataType: "ARRAY<ENUM<examples.music.Genre>>", Kind: "ENUM", Name: "examples.music.Genre",
+				GoType: "musicpb.Genre", ImportPath: "example.com/music/musicpb", IsArray: true,
+			},
+		},
+		{
+			dataType:    "PROTO<examples.music.Album>",
+			expectedErr: "unknown proto type examples.music.Album: add it to protoTypes in the config",
+		},
+		{
+			dataType:    "PROTO<examples.music.Genre>",
+			expectedErr: "proto type examples.music.Genre is PROTO but enum in the config is true",
+		},
+	}
+
+	for _, tc := range table {
+		t.Run(tc.dataType, func(t *testing.T) {
+			got, err := l.resolveProtoType(tc.dataType)
+			if tc.expectedErr != "" {
+				if err == nil || errorMessage(err) != tc.expectedErr {
+					t.Fatalf("expect error %q, but got %v", tc.expectedErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("failed to resolve: %v", err)
+			}
+
+			if diff := cmp.Diff(got, tc.expected); diff != "" {
+				t.Errorf("(-got, +want)\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestLoader_Dialect(t *testing.T) {
+	schema := `
+CREATE TABLE Items (
+  Id INT64 NOT NULL,
+) PRIMARY KEY(Id);
+`
+
+	table := []struct {
+		name        string
+		dialect     string
+		expectedErr string
+	}{
+		{
+			name: "Default",
+		},
+		{
+			name:    "GoogleSQL",
+			dialect: models.DialectGoogleSQL,
+		},
+		{
+			name:        "Mismatch",
+			dialect:     models.DialectPostgreSQL,
+			expectedErr: "dialect POSTGRESQL in the config does not match the dialect GOOGLE_STANDARD_SQL of the schema",
+		},
+		{
+			name:        "Unknown",
+			dialect:     "MYSQL",
+			expectedErr: "unknown dialect MYSQL: must be GOOGLE_STANDARD_SQL or POSTGRESQL",
+		},
+	}
+
+	for _, tc := range table {
+		t.Run(tc.name, func(t *testing.T) {
+			l := setUpTypeLoader(t, schema, Option{Config: &config.Config{Dialect: tc.dialect}})
+			s, err := l.LoadSchema()
+			if tc.expectedErr != "" {
+				if err == nil || errorMessage(err) != tc.expectedErr {
+					t.Fatalf("expect error %q, but got %v", tc.expectedErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("failed to load schema: %v", err)
+			}
+
+			if s.Dialect != models.DialectGoogleSQL {
+				t.Errorf("expect dialect %s, but got %s", models.DialectGoogleSQL, s.Dialect)
+			}
+			if p := l.NthParam(0); p != "@param0" {
+				t.Errorf("expect param @param0, but got %s", p)
+			}
+			if p := l.NthParamName(0); p != "param0" {
+				t.Errorf("expect param name param0, but got %s", p)
+			}
+		})
+	}
+}
+
+func TestTypeLoader_NthParam_PostgreSQL(t *testing.T) {
+	l := &TypeLoader{dialect: models.DialectPostgreSQL}
+
+	if p := l.NthParam(0); p != "$1" {
+		t.Errorf("expect param $1, but got %s", p)
+	}
+	if p := l.NthParamName(1); p != "p2" {
+		t.Errorf("expect param name p2, but got %s", p)
+	}
+}
+
+func Test_parseSpannerType(t *testing.T) {
+	table := []struct {
+		dataType string
+		nullable bool
+		len      int
+		nilVal   string
+		typ      string
+	}{
+		{dataType: "STRING(32)", len: 32, nilVal: `""`, typ: "string"},
+		{dataType: "FLOAT32", len: -1, nilVal: "0.0", typ: "float32"},
+		{dataType: "FLOAT32", nullable: true, len: -1, nilVal: "spanner.NullFloat32{}", typ: "spanner.NullFloat32"},
+		{dataType: "INTERVAL", len: -1, nilVal: "spanner.Interval{}", typ: "spanner.Interval"},
+		{dataType: "INTERVAL", nullable: true, len: -1, nilVal: "spanner.NullInterval{}", typ: "spanner.NullInterval"},
+		{dataType: "UUID", len: -1, nilVal: "uuid.UUID{}", typ: "uuid.UUID"},
+		{dataType: "UUID", nullable: true, len: -1, nilVal: "spanner.NullUUID{}", typ: "spanner.NullUUID"},
+		{dataType: "TOKENLIST", nullable: true, len: -1, nilVal: "nil", typ: "[]byte"},
+		{dataType: "ARRAY<FLOAT32>", len: -1, nilVal: "[]float32{}", typ: "[]float32"},
+		{dataType: "ARRAY<UUID>", nullable: true, len: -1, nilVal: "nil", typ: "[]uuid.UUID"},
+		{dataType: "ARRAY<INTERVAL>", nullable: true, len: -1, nilVal: "nil", typ: "[]spanner.Interval"},
+		{dataType: "ARRAY<FLOAT64>(vector_length=>3)", nullable: true, len: -1, nilVal: "nil", typ: "[]float64"},
+	}
+
+	for _, tc := range table {
+		t.Run(tc.dataType, func(t *testing.T) {
+			len, nilVal, typ, err := parseSpannerType(tc.dataType, tc.nullable, models.DialectGoogleSQL)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len != tc.len || nilVal != tc.nilVal || typ != tc.typ {
+				t.Errorf("expect (%d, %s, %s), but got (%d, %s, %s)", tc.len, tc.nilVal, tc.typ, len, nilVal, typ)
+			}
+		})
+	}
+
+	for _, dataType := range []string{"GEOGRAPHY", "ARRAY<GEOGRAPHY>"} {
+		if _, _, _, err := parseSpannerType(dataType, false, models.DialectGoogleSQL); err == nil {
+			t.Errorf("expected an error for %s, but got nil", dataType)
+		}
+	}
+}
+
+func TestLoader_ConfigTypes(t *testing.T) {
+	schema := `
+CREATE TABLE Items (
+  Id UUID NOT NULL,
+  ParentId UUID,
+  Tags ARRAY<UUID>,
+  Embedding ARRAY<FLOAT32>(vector_length=>3),
+  Name STRING(MAX),
+  Name_Tokens TOKENLIST AS (TOKENIZE_FULLTEXT(Name)) HIDDEN,
+) PRIMARY KEY(Id);
+`
+
+	l := setUpTypeLoader(t, schema, Option{
+		Config: &config.Config{
+			Types: []config.Type{
+				{SpannerType: "UUID", GoType: "gid.ID", NullGoType: "gid.NullID", ImportPath: "example.com/gid"},
+			},
+		},
+	})
+	s, err := l.LoadSchema()
+	if err != nil {
+		t.Fatalf("failed to load schema: %v", err)
+	}
+
+	type field struct {
+		Type       string
+		ImportPath string
+	}
+	got := make(map[string]field)
+	for _, f := range s.Types[0].Fields {
+		got[f.ColumnName] = field{Type: f.Type, ImportPath: f.ImportPath}
+	}
+
+	expected := map[string]field{
+		"Id":          {Type: "gid.ID", ImportPath: "example.com/gid"},
+		"ParentId":    {Type: "gid.NullID", ImportPath: "example.com/gid"},
+		"Tags":        {Type: "[]gid.ID", ImportPath: "example.com/gid"},
+		"Embedding":   {Type: "[]float32"},
+		"Name":        {Type: "spanner.NullString"},
+		"Name_Tokens": {Type: "[]byte"},
+	}
+	if diff := cmp.Diff(got, expected); diff != "" {
+		t.Errorf("(-got, +want)\n%s", diff)
+	}
+}
+
+func TestLoader_TokenList(t *testing.T) {
+	schema := `
+CREATE TABLE Items (
+  Id INT64 NOT NULL,
+  Name STRING(MAX),
+  Name_Tokens TOKENLIST AS (TOKENIZE_FULLTEXT(Name)),
+  Name_HiddenTokens TOKENLIST AS (TOKENIZE_FULLTEXT(Name)) HIDDEN,
+) PRIMARY KEY(Id);
+`
+
+	l := setUpTypeLoader(t, schema, Option{})
+	s, err := l.LoadSchema()
+	if err != nil {
+		t.Fatalf("failed to load schema: %v", err)
+	}
+
+	got := make(map[string]bool)
+	for _, f := range s.Types[0].Fields {
+		got[f.ColumnName] = f.IsHidden
+	}
+
+	expected := map[string]bool{
+		"Id":                false,
+		"Name":              false,
+		"Name_Tokens":       true,
+		"Name_HiddenTokens": true,
+	}
+	if diff := cmp.Diff(got, expected); diff != "" {
+		t.Errorf("(-got, +want)\n%s", diff)
+	}
+}
+
+// postgreSQLSource is a SchemaSource reporting the columns of SchemaSource
+// with the types of PostgreSQL in types.
+type postgreSQLSource struct {
+	SchemaSource
+	types map[string]string
+}
+
+func (s *postgreSQLSource) Dialect() (string, error) {
+	return models.DialectPostgreSQL, nil
+}
+
+func (s *postgreSQLSource) ColumnList(table string) ([]*SpannerColumn, error) {
+	cols, err := s.SchemaSource.ColumnList(table)
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range cols {
+		if t, ok := s.types[c.DataType]; ok {
+			c.DataType = t
+		}
+	}
+	return cols, nil
+}
+
+func TestLoader_TokenList_PostgreSQL(t *testing.T) {
+	schema := `
+CREATE TABLE Items (
+  Id INT64 NOT NULL,
+  Name STRING(MAX),
+  Name_Tokens TOKENLIST AS (TOKENIZE_FULLTEXT(Name)),
+) PRIMARY KEY(Id);
+`
+
+	l := setUpTypeLoader(t, schema, Option{})
+	inflector, err := internal.NewInflector(nil)
+	if err != nil {
+		t.Fatalf("failed to create inflector: %v", err)
+	}
+	l = NewTypeLoader(&postgreSQLSource{
+		SchemaSource: l.source,
+		types: map[string]string{
+			"INT64":       "bigint",
+			"STRING(MAX)": "character varying",
+			"TOKENLIST":   "spanner.tokenlist",
+		},
+	}, inflector, Option{})
+
+	s, err := l.LoadSchema()
+	if err != nil {
+		t.Fatalf("failed to load schema: %v", err)
+	}
+
+	got := make(map[string]bool)
+	for _, f := range s.Types[0].Fields {
+		got[f.ColumnName] = f.IsHidden
+	}
+
+	expected := map[string]bool{
+		"Id":          false,
+		"Name":        false,
+		"Name_Tokens": true,
+	}
+	if diff := cmp.Diff(got, expected); diff != "" {
+		t.Errorf("(-got, +want)\n%s", diff)
+	}
+}
+
+func TestLoader_UnsupportedType(t *testing.T) {
+	schema := `
+CREATE TABLE Places (
+  Id INT64 NOT NULL,
+  Location GEOGRAPHY,
+) PRIMARY KEY(Id);
+`
+
+	l := setUpTypeLoader(t, schema, Option{})
+	_, err := l.LoadSchema()
+	if err == nil {
+		t.Fatal("expected an error, but got nil")
+	}
+
+	expected := "schema.sql:4:3: column Location in the table Places: unsupported type GEOGRAPHY: add it to types in the config"
+	if msg := errorMessage(err); msg != expected {
+		t.Errorf("expected error %q, but got %q", expected, msg)
+	}
+}
+
+func Test_parseSpannerType_PostgreSQL(t *testing.T) {
+	table := []struct {
+		dataType string
+		nullable bool
+		len      int
+		nilVal   string
+		typ      string
+	}{
+		{dataType: "bigint", len: -1, nilVal: "0", typ: "int64"},
+		{dataType: "bigint", nullable: true, len: -1, nilVal: "spanner.NullInt64{}", typ: "spanner.NullInt64"},
+		{dataType: "boolean", len: -1, nilVal: "false", typ: "bool"},
+		{dataType: "double precision", len: -1, nilVal: "0.0", typ: "float64"},
+		{dataType: "character varying(256)", len: 256, nilVal: `""`, typ: "string"},
+		{dataType: "character varying", nullable: true, len: -1, nilVal: "spanner.NullString{}", typ: "spanner.NullString"},
+		{dataType: "text", len: -1, nilVal: `""`, typ: "string"},
+		{dataType: "bytea", nullable: true, len: -1, nilVal: "nil", typ: "[]byte"},
+		{dataType: "timestamp with time zone", len: -1, nilVal: "time.Time{}", typ: "time.Time"},
+		{dataType: "spanner.commit_timestamp", nullable: true, len: -1, nilVal: "spanner.NullTime{}", typ: "spanner.NullTime"},
+		{dataType: "date", len: -1, nilVal: "civil.Date{}", typ: "civil.Date"},
+		{dataType: "numeric", len: -1, nilVal: `spanner.PGNumeric{Numeric: "0", Valid: true}`, typ: "spanner.PGNumeric"},
+		{dataType: "numeric", nullable: true, len: -1, nilVal: "spanner.PGNumeric{}", typ: "spanner.PGNumeric"},
+		{dataType: "jsonb", len: -1, nilVal: "spanner.PGJsonB{Valid: true}", typ: "spanner.PGJsonB"},
+		{dataType: "bigint[]", nullable: true, len: -1, nilVal: "nil", typ: "[]int64"},
+		{dataType: "character varying(32)[]", len: -1, nilVal: "[]string{}", typ: "[]string"},
+		{dataType: "jsonb[]", nullable: true, len: -1, nilVal: "nil", typ: "[]spanner.PGJsonB"},
+		{dataType: "real", len: -1, nilVal: "0.0", typ: "float32"},
+		{dataType: "interval", nullable: true, len: -1, nilVal: "spanner.NullInterval{}", typ: "spanner.NullInterval"},
+		{dataType: "uuid", len: -1, nilVal: "uuid.UUID{}", typ: "uuid.UUID"},
+		{dataType: "uuid[]", nullable: true, len: -1, nilVal: "nil", typ: "[]uuid.UUID"},
+		{dataType: "spanner.tokenlist", nullable: true, len: -1, nilVal: "nil", typ: "[]byte"},
+	}
+
+	for _, tc := range table {
+		t.Run(tc.dataType, func(t *testing.T) {
+			len, nilVal, typ, err := parseSpannerType(tc.dataType, tc.nullable, models.DialectPostgreSQL)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len != tc.len || nilVal != tc.nilVal || typ != tc.typ {
+				t.Errorf("expect (%d, %s, %s), but got (%d, %s, %s)", tc.len, tc.nilVal, tc.typ, len, nilVal, typ)
+			}
+		})
+	}
+}
+
+func TestLoader_ChangeStreams(t *testing.T) {
+	schema := `
+CREATE TABLE Singers (
+  SingerId INT64 NOT NULL,
+  FirstName STRING(32),
+  LastName STRING(32),
+) PRIMARY KEY(SingerId);
+
+CREATE TABLE Albums (
+  AlbumId INT64 NOT NULL,
+  Title STRING(32),
+) PRIMARY KEY(AlbumId);
+
+CREATE VIEW SingerNames SQL SECURITY INVOKER AS SELECT FirstName FROM Singers;
+
+CREATE CHANGE STREAM EverythingStream FOR ALL;
+
+CREATE CHANGE STREAM NamesStream
+  FOR Singers(LastName, FirstName), Albums()
+  OPTIONS (retention_period = '36h', value_capture_type = 'NEW_ROW', exclude_ttl_deletes = true);
+
+CREATE CHANGE STREAM AlbumsStream FOR Albums;
+`
+
+	type streamTable struct {
+		Table      string
+		AllColumns bool
+		Columns    []string
+	}
+	type stream struct {
+		Name    string
+		All     bool
+		Tables  []streamTable
+		Options map[string]string
+	}
+
+	table := []struct {
+		name            string
+		opt             Option
+		expected        map[string]stream
+		expectedStreams map[string][]string
+	}{
+		{
+			name: "All",
+			opt:  Option{},
+			expected: map[string]stream{
+				"AlbumsStream": {
+					Name:    "AlbumsStream",
+					Tables:  []streamTable{{Table: "Albums", AllColumns: true}},
+					Options: map[string]string{},
+				},
+				"EverythingStream": {
+					Name: "EverythingStream",
+					All:  true,
+					Tables: []streamTable{
+						{Table: "Albums", AllColumns: true},
+						{Table: "Singers", AllColumns: true},
+					},
+					Options: map[string]string{},
+				},
+				"NamesStream": {
+					Name: "NamesStream",
+					Tables: []streamTable{
+						{Table: "Albums"},
+						{Table: "Singers", Columns: []string{"FirstName", "LastName"}},
+					},
+					Options: map[string]string{
+						"retention_period":    "36h",
+						"value_capture_type":  "NEW_ROW",
+						"exclude_ttl_deletes": "TRUE",
+					},
+				},
+			},
+			expectedStreams: map[string][]string{
+				"Albums":      {"AlbumsStream", "EverythingStream", "NamesStream"},
+				"Singers":     {"EverythingStream", "NamesStream"},
+				"SingerNames": nil,
+			},
+		},
+		{
+			name: "IgnoreTableAndField",
+			opt:  Option{IgnoreTables: []string{"Albums"}, IgnoreFields: []string{"LastName"}},
+			expected: map[string]stream{
+				"AlbumsStream": {
+					Name:    "AlbumsStream",
+					Options: map[string]string{},
+				},
+				"EverythingStream": {
+					Name:    "EverythingStream",
+					All:     true,
+					Tables:  []streamTable{{Table: "Singers", AllColumns: true}},
+					Options: map[string]string{},
+				},
+				"NamesStream": {
+					Name:   "NamesStream",
+					Tables: []streamTable{{Table: "Singers", Columns: []string{"FirstName"}}},
+					Options: map[string]string{
+						"retention_period":    "36h",
+						"value_capture_type":  "NEW_ROW",
+						"exclude_ttl_deletes": "TRUE",
+					},
+				},
+			},
+			expectedStreams: map[string][]string{
+				"Singers":     {"EverythingStream", "NamesStream"},
+				"SingerNames": nil,
+			},
+		},
+	}
+
+	for _, tc := range table {
+		t.Run(tc.name, func(t *testing.T) {
+			l := setUpTypeLoader(t, schema, tc.opt)
+
+			schema, err := l.LoadSchema()
+			if err != nil {
+				t.Fatalf("failed to load schema: %v", err)
+			}
+
+			got := make(map[string]stream)
+			for _, cs := range schema.ChangeStreams {
+				v := stream{
+					Name:    cs.Name,
+					All:     cs.All,
+					Options: cs.Options,
+				}
+				for _, ct := range cs.Tables {
+					st := streamTable{Table: ct.Type.TableName, AllColumns: ct.AllColumns}
+					for _, f := range ct.Fields {
+						st.Columns = append(st.Columns, f.ColumnName)
+					}
+					v.Tables = append(v.Tables, st)
+				}
+				got[cs.StreamName] = v
+			}
+
+			if diff := cmp.Diff(got, tc.expected); diff != "" {
+				t.Errorf("(-got, +want)\n%s", diff)
+			}
+
+			gotStreams := make(map[string][]string)
+			for _, typ := range schema.Types {
+				var names []string
+				for _, cs := range typ.ChangeStreams {
+					names = append(names, cs.StreamName)
+				}
+				gotStreams[typ.TableName] = names
+			}
+
+			if diff := cmp.Diff(gotStreams, tc.expectedStreams); diff != "" {
+				t.Errorf("(-got, +want)\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestLoader_CustomTypes(t *testing.T) {
+	table := []struct {
+		name           string
+		opt            Option
+		schema         string
+		expectedSchema *models.Schema
+		expectedErr    string
+	}{
+		{
+			name: "Table does not exist",
+			opt: Option{
+				Config: &config.Config{
+					Tables: []config.Table{
+						{
+							Name: "UnknownTable",
+						},
+					},
+				},
+			},
+			schema:      simpleSchema,
+			expectedErr: "unknown table UnknownTable in the config",
+		},
+		{
+			name: "Custom type column does not exist",
+			opt: Option{
+				Config: &config.Config{
+					Tables: []config.Table{
+						{
+							Name: "Simple",
+							Columns: []config.Column{
+								{
+									Name:       "UnknownColumn",
+									CustomType: "UnknownCustomColumn",
+								},
+							},
+						},
+					},
+				},
+			},
+			schema:      simpleSchema,
+			expectedErr: "schema.sql:2:1: unknown custom type column UnknownColumn in the table Simple",
+		},
+		{
+			name: "Success",
+			opt: Option{
+				Config: &config.Config{
+					Tables: []config.Table{
+						{
+							Name: "Simple",
+							Columns: []config.Column{
+								{
+									Name:       "Value",
+									CustomType: "Value",
+								},
+							},
+						},
+					},
+				},
+			},
+			schema: simpleSchema,
+			expectedSchema: &models.Schema{
+				Types: []*models.Type{
+					{
+						Name: "Simple",
+						PrimaryKeyFields: []*models.Field{
+							{ColumnName: "Id"},
+						},
+						PrimaryKeys: []*models.IndexKey{
+							{Field: &models.Field{ColumnName: "Id"}},
+						},
+						Fields: []*models.Field{
+							{
+								Name:            "ID",
+								Type:            "int64",
+								OriginalType:    "int64",
+								NullValue:       "0",
+								Len:             -1,
+								ColumnName:      "Id",
+								SpannerDataType: "INT64",
+								IsNotNull:       true,
+								IsPrimaryKey:    true,
+							},
+							{
+								Name:            "Value",
+								Type:            "Value",
+								OriginalType:    "string",
+								NullValue:       `""`,
+								Len:             32,
+								ColumnName:      "Value",
+								SpannerDataType: "STRING(32)",
+								IsNotNull:       true,
+								IsPrimaryKey:    false,
+							},
+						},
+						TableName: "Simple",
+						Indexes: []*models.Index{
+							{
+								Name:           "SimpleIndex",
+								FuncName:       "SimplesBySimpleIndex",
+								LegacyFuncName: "SimplesByValue",
+								Fields: []*models.Field{
+									{ColumnName: "Value"},
+								},
+								Keys: []*models.IndexKey{
+									{Field: &models.Field{ColumnName: "Value"}},
+								},
+								IndexName: "SimpleIndex",
+								IndexType: models.IndexTypeIndex,
+							},
+							{
+								Name:           "SimpleIndex2",
+								FuncName:       "SimpleBySimpleIndex2",
+								LegacyFuncName: "SimpleByIDValue",
+								Fields: []*models.Field{
+									{ColumnName: "Id"},
+									{ColumnName: "Value"},
+								},
+								Keys: []*models.IndexKey{
+									{Field: &models.Field{ColumnName: "Id"}},
+									{Field: &models.Field{ColumnName: "Value"}},
+								},
+								IndexName: "SimpleIndex2",
+								IndexType: models.IndexTypeIndex,
+								IsUnique:  true,
+							},
+						},
+					},
+				},
+				Dialect: models.DialectGoogleSQL,
+			},
+		},
+	}
+
+	for _, tc := range table {
+		t.Run(tc.name, func(t *testing.T) {
+			l := setUpTypeLoader(t, tc.schema, tc.opt)
+
+			schema, err := l.LoadSchema()
+
+			if tc.expectedErr != "" {
+				if err == nil {
+					t.Fatal("expected to load schema failure")
+				}
+
+				if errorMessage(err) != tc.expectedErr {
+					t.Fatalf("unexpected error: expected: %s, actual: %s", tc.expectedErr, err.Error())
+				}
+			} else {
+				if err != nil {
+					t.Fatalf("failed to load schema: %v", err)
+				}
+
+				compareSchemas(t, schema, tc.expectedSchema)
+			}
+		})
+	}
+}
+
+func TestLoader_ConfigOverrides(t *testing.T) {
+	schema := `
+CREATE TABLE Data (
+  Id INT64 NOT NULL,
+  Value STRING(MAX),
+  Secret STRING(MAX),
+) PRIMARY KEY(Id);
+
+CREATE INDEX DataByValue ON Data(Value);
+
+CREATE TABLE Tmp1 (
+  Id INT64 NOT NULL,
+) PRIMARY KEY(Id);
+
+CREATE TABLE Tmp2 (
+  Id INT64 NOT NULL,
+) PRIMARY KEY(Id);
+
+CREATE TABLE Logs (
+  Id INT64 NOT NULL,
+) PRIMARY KEY(Id);
+`
+
+	l := setUpTypeLoader(t, schema, Option{
+		Config: &config.Config{
+			Tables: []config.Table{
+				{
+					Name:     "Data",
+					TypeName: "Data",
+					Columns: []config.Column{
+						{Name: "Value", FieldName: "Val"},
+					},
+					IgnoreColumns: []string{"Secret"},
+					Indexes: []config.Index{
+						{Name: "DataByValue", FuncName: "DataByVal"},
+					},
+				},
+			},
+			IncludeTables: []string{"Data", "/^Tmp[0-9]+$/", "Logs"},
+			ExcludeTables: []string{"*2", "Log?"},
+		},
+	})
+	s, err := l.LoadSchema()
+	if err != nil {
+		t.Fatalf("failed to load schema: %v", err)
+	}
+
+	type index struct {
+		FuncName       string
+		LegacyFuncName string
+	}
+	type typ struct {
+		Name    string
+		Fields  []string
+		Indexes []index
+	}
+	var got []typ
+	for _, t := range s.Types {
+		v := typ{Name: t.Name}
+		for _, f := range t.Fields {
+			v.Fields = append(v.Fields, f.Name)
+		}
+		for _, ix := range t.Indexes {
+			v.Indexes = append(v.Indexes, index{FuncName: ix.FuncName, LegacyFuncName: ix.LegacyFuncName})
+		}
+		got = append(got, v)
+	}
+
+	expected := []typ{
+		{
+			Name:    "Data",
+			Fields:  []string{"ID", "Val"},
+			Indexes: []index{{FuncName: "DataByVal", LegacyFuncName: "DataByVal"}},
+		},
+		{
+			Name:   "Tmp1",
+			Fields: []string{"ID"},
+		},
+	}
+	if diff := cmp.Diff(got, expected); diff != "" {
+		t.Errorf("(-got, +want)\n%s", diff)
+	}
+}
+
+func TestLoader_ConfigOverrides_Errors(t *testing.T) {
+	schema := `
+CREATE TABLE Items (
+  Id INT64 NOT NULL,
+  item_id INT64,
+) PRIMARY KEY(Id);
+
+CREATE TABLE Item (
+  Id INT64 NOT NULL,
+) PRIMARY KEY(Id);
+`
+
+	table := map[string]struct {
+		cfg      *config.Config
+		expected string
+	}{
+		"UnknownColumn": {
+			cfg: &config.Config{
+				Tables: []config.Table{
+					{Name: "Item", Columns: []config.Column{{Name: "Unknown", FieldName: "Foo"}}},
+				},
+			},
+			expected: "schema.sql:7:1: unknown column Unknown in the table Item in the config",
+		},
+		"UnknownIgnoredColumn": {
+			cfg: &config.Config{
+				Tables: []config.Table{
+					{Name: "Item", IgnoreColumns: []string{"Unknown"}},
+				},
+			},
+			expected: "schema.sql:7:1: unknown ignored column Unknown in the table Item in the config",
+		},
+		"UnknownIndex": {
+			cfg: &config.Config{
+				Tables: []config.Table{
+					{Name: "Item", Indexes: []config.Index{{Name: "Unknown", FuncName: "Foo"}}},
+				},
+				ExcludeTables: []string{"Items"},
+			},
+			expected: "schema.sql:7:1: unknown index Unknown in the table Item in the config",
+		},
+		"DuplicateTypeName": {
+			cfg:      &config.Config{},
+			expected: "type name Item is used by both of the tables Item and Items: set typeName in the config",
+		},
+		"DuplicateFieldName": {
+			cfg: &config.Config{
+				Tables: []config.Table{
+					{Name: "Items", Columns: []config.Column{{Name: "item_id", FieldName: "ID"}}},
+				},
+				ExcludeTables: []string{"Item"},
+			},
+			expected: "schema.sql:4:3: field name ID is used by both of the columns Id and item_id in the table Items: set fieldName in the config",
+		},
+		"InvalidPattern": {
+			cfg: &config.Config{
+				ExcludeTables: []string{"/[/"},
+			},
+			expected: "invalid table pattern /[/: error parsing regexp: missing closing ]: `[`",
+		},
+	}
+
+	for name, tc := range table {
+		t.Run(name, func(t *testing.T) {
+			l := setUpTypeLoader(t, schema, Option{Config: tc.cfg})
+			_, err := l.LoadSchema()
+			if err == nil {
+				t.Fatal("expected an error, but got nil")
+			}
+			if msg := errorMessage(err); msg != tc.expected {
+				t.Errorf("expected error %q, but got %q", tc.expected, msg)
+			}
+		})
+	}
+}
+
+func TestLoader_Tags(t *testing.T) {
+	schema := `
+CREATE TABLE Singers (
+  SingerId INT64 NOT NULL,
+  FirstName STRING(MAX),
+  Secret STRING(MAX),
+) PRIMARY KEY(SingerId);
+`
+
+	table := map[string]struct {
+		cfg      *config.Config
+		expected map[string][]*models.Tag
+	}{
+		"Default": {
+			cfg: &config.Config{},
+			expected: map[string][]*models.Tag{
+				"SingerId":  {{Key: "spanner", Value: "SingerId"}, {Key: "json", Value: "SingerId"}},
+				"FirstName": {{Key: "spanner", Value: "FirstName"}, {Key: "json", Value: "FirstName"}},
+				"Secret":    {{Key: "spanner", Value: "Secret"}, {Key: "json", Value: "Secret"}},
+			},
+		},
+		"SnakeCase": {
+			cfg: &config.Config{
+				Tags: config.Tags{JSONNaming: "snake_case", JSONOmitEmpty: true},
+			},
+			expected: map[string][]*models.Tag{
+				"SingerId":  {{Key: "spanner", Value: "SingerId"}, {Key: "json", Value: "singer_id,omitempty"}},
+				"FirstName": {{Key: "spanner", Value: "FirstName"}, {Key: "json", Value: "first_name,omitempty"}},
+				"Secret":    {{Key: "spanner", Value: "Secret"}, {Key: "json", Value: "secret,omitempty"}},
+			},
+		},
+		"ExtraAndOverrides": {
+			cfg: &config.Config{
+				Tables: []config.Table{
+					{
+						Name: "Singers",
+						Columns: []config.Column{
+							{Name: "FirstName", FieldName: "Name", Tags: map[string]string{"validate": "max=64", "firestore": "name"}},
+							{Name: "Secret", Tags: map[string]string{"json": "-", "bigquery": ""}},
+						},
+					},
+				},
+				Tags: config.Tags{
+					JSONNaming: "lowerCamelCase",
+					Extra: []config.Tag{
+						{Key: "bigquery", Value: "{{ snake .Name }}"},
+						{Key: "validate", Value: "{{ if .IsNotNull }}required{{ end }}"},
+					},
+				},
+			},
+			expected: map[string][]*models.Tag{
+				"SingerId": {
+					{Key: "spanner", Value: "SingerId"},
+					{Key: "json", Value: "singerID"},
+					{Key: "bigquery", Value: "singer_id"},
+					{Key: "validate", Value: "required"},
+				},
+				"FirstName": {
+					{Key: "spanner", Value: "FirstName"},
+					{Key: "json", Value: "firstName"},
+					{Key: "bigquery", Value: "name"},
+					{Key: "validate", Value: "max=64"},
+					{Key: "firestore", Value: "name"},
+				},
+				"Secret": {
+					{Key: "spanner", Value: "Secret"},
+					{Key: "json", Value: "-"},
+				},
+			},
+		},
+	}
+
+	for name, tc := range table {
+		t.Run(name, func(t *testing.T) {
+			l := setUpTypeLoader(t, schema, Option{Config: tc.cfg})
+			s, err := l.LoadSchema()
+			if err != nil {
+				t.Fatalf("failed to load schema: %v", err)
+			}
+
+			got := make(map[string][]*models.Tag)
+			for _, f := range s.Types[0].Fields {
+				got[f.ColumnName] = f.Tags
+			}
+			if diff := cmp.Diff(got, tc.expected); diff != "" {
+				t.Errorf("(-got, +want)\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestLoader_Tags_Errors(t *testing.T) {
+	schema := `
+CREATE TABLE Singers (
+  SingerId INT64 NOT NULL,
+) PRIMARY KEY(SingerId);
+`
+
+	table := map[string]struct {
+		cfg      *config.Config
+		expected string
+	}{
+		"UnknownJSONNaming": {
+			cfg:      &config.Config{Tags: config.Tags{JSONNaming: "kebab"}},
+			expected: "unknown jsonNaming kebab in the config: it must be column, snake_case, lowerCamelCase or field",
+		},
+		"DuplicateTag": {
+			cfg:      &config.Config{Tags: config.Tags{Extra: []config.Tag{{Key: "json", Value: "x"}}}},
+			expected: "duplicate tag json in the config",
+		},
+		"InvalidKey": {
+			cfg:      &config.Config{Tags: config.Tags{Extra: []config.Tag{{Key: "big query", Value: "x"}}}},
+			expected: `invalid tag key "big query"`,
+		},
+		"InvalidTemplate": {
+			cfg:      &config.Config{Tags: config.Tags{Extra: []config.Tag{{Key: "bigquery", Value: "{{ .Name "}}}},
+			expected: "invalid value of tag bigquery in the config: template: bigquery:1: unclosed action",
+		},
+		"OverrideSpanner": {
+			cfg: &config.Config{
+				Tables: []config.Table{
+					{Name: "Singers", Columns: []config.Column{{Name: "SingerId", Tags: map[string]string{"spanner": "id"}}}},
+				},
+			},
+			expected: "schema.sql:3:3: column SingerId in the table Singers: tag spanner cannot be overridden",
+		},
+		"Quote": {
+			cfg: &config.Config{
+				Tables: []config.Table{
+					{Name: "Singers", Columns: []config.Column{{Name: "SingerId", Tags: map[string]string{"validate": `oneof="a"`}}}},
+				},
+			},
+			expected: `schema.sql:3:3: column SingerId in the table Singers: value of tag validate must not contain quotes: oneof="a"`,
+		},
+	}
+
+	for name, tc := range table {
+		t.Run(name, func(t *testing.T) {
+			l := setUpTypeLoader(t, schema, Option{Config: tc.cfg})
+			_, err := l.LoadSchema()
+			if err == nil {
+				t.Fatal("expected an error, but got nil")
+			}
+			if msg := errorMessage(err); msg != tc.expected {
+				t.Errorf("expected error %q, but got %q", tc.expected, msg)
+			}
+		})
+	}
+}
+
+func Test_setIndexesToTables(t *testing.T) {
+	tests := []struct {
+		table  map[string]*models.Type
+		ix     map[string]*models.Index
+		result map[string]int
+	}{
+		{
+			table: map[string]*models.Type{
+				"TableA": &models.Type{
+					Indexes: []*models.Index{},
+				},
+			},
+			ix: map[string]*models.Index{
+				"TableA_Index1": &models.Index{
+					Type: &models.Type{
+						TableName: "TableA",
+					},
+					IndexName: "Index1",
+				},
+				"TableA_Index2": &models.Index{
+					Type: &models.Type{
+						TableName: "TableA",
+					},
+					IndexName: "Index2",
+				},
+			},
+			result: map[string]int{
+				"TableA": 2,
 			},
 		},
 		{
@@ -616,15 +2859,10 @@ func Test_setIndexesToTables(t *testing.T) {
 func setUpTypeLoader(t *testing.T, schema string, opt Option) *TypeLoader {
 	t.Helper()
 
-	dir := t.TempDir()
-
-	f, err := os.CreateTemp(dir, "")
-	if err != nil {
-		t.Fatalf("failed to create temp file: %v", err)
+	path := filepath.Join(t.TempDir(), "schema.sql")
+	if err := os.WriteFile(path, []byte(schema), 0o644); err != nil {
+		t.Fatalf("failed to write schema: %v", err)
 	}
-	_, _ = f.Write([]byte(schema))
-	_ = f.Close()
-	path := f.Name()
 
 	source, err := NewSchemaParserSource(path)
 	if err != nil {
@@ -639,6 +2877,16 @@ func setUpTypeLoader(t *testing.T, schema string, opt Option) *TypeLoader {
 	return NewTypeLoader(source, inflector, opt)
 }
 
+// errorMessage returns the message of err without the directory of the
+// schema file created by setUpTypeLoader.
+func errorMessage(err error) string {
+	msg := err.Error()
+	if i := strings.Index(msg, string(filepath.Separator)+"schema.sql:"); i >= 0 {
+		msg = msg[i+1:]
+	}
+	return msg
+}
+
 func compareSchemas(t *testing.T, actual *models.Schema, expected *models.Schema) {
 	t.Helper()
 
@@ -651,6 +2899,10 @@ func compareSchemas(t *testing.T, actual *models.Schema, expected *models.Schema
 				f := in.PrimaryKeyFields[i]
 				in.PrimaryKeyFields[i] = &models.Field{ColumnName: f.ColumnName}
 			}
+			for i := range in.PrimaryKeys {
+				k := in.PrimaryKeys[i]
+				in.PrimaryKeys[i] = &models.IndexKey{Field: &models.Field{ColumnName: k.Field.ColumnName}, Desc: k.Desc}
+			}
 			return in
 		}),
 		cmp.Transformer("FilterInIndexFields", func(in *models.Index) *models.Index {
@@ -658,10 +2910,20 @@ func compareSchemas(t *testing.T, actual *models.Schema, expected *models.Schema
 				f := in.Fields[i]
 				in.Fields[i] = &models.Field{ColumnName: f.ColumnName}
 			}
+			for i := range in.Keys {
+				k := in.Keys[i]
+				in.Keys[i] = &models.IndexKey{Field: &models.Field{ColumnName: k.Field.ColumnName}, Desc: k.Desc}
+			}
 			return in
 		}),
 		cmpopts.IgnoreFields(models.Index{}, "Type"),
+		cmpopts.IgnoreFields(models.Type{}, "Parent", "Children"),
+		cmpopts.IgnoreFields(models.Field{}, "Tags"),
 	); diff != "" {
 		t.Errorf("(-got, +want)\n%s", diff)
 	}
 }
+
+func boolPtr(b bool) *bool {
+	return &b
+}
@@ -22,111 +22,769 @@ package loader
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 	"sort"
 	"strings"
 
 	"github.com/cloudspannerecosystem/memefish"
 	"github.com/cloudspannerecosystem/memefish/ast"
 	"github.com/cloudspannerecosystem/memefish/token"
+
+	"go.mercari.io/yo/v2/models"
 )
 
+// extractName returns the name of path. A name in a named schema is returned
+// as a fully qualified name such as schema.name.
 func extractName(path *ast.Path) (string, error) {
-	if len(path.Idents) != 1 {
+	switch len(path.Idents) {
+	case 1:
+		return path.Idents[0].Name, nil
+	case 2:
+		return qualifiedName(path.Idents[0].Name, path.Idents[1].Name), nil
+	default:
 		return "", fmt.Errorf("path isn't simple ident: %v", path.SQL())
 	}
-	return path.Idents[0].Name, nil
 }
 
+// NewSchemaParserSource creates a SchemaSource from DDL files. fpath is a DDL
+// file, a directory of migration files or a glob pattern. The statements in
+// the files are applied in order of the file names, so ALTER and DROP
+// statements in a later file modify the schema defined by the earlier ones.
 func NewSchemaParserSource(fpath string) (SchemaSource, error) {
-	b, err := os.ReadFile(fpath)
+	files, err := ddlFiles(fpath)
 	if err != nil {
 		return nil, err
 	}
 
-	tables := make(map[string]table)
-	stmts := strings.Split(string(b), ";")
-	for _, stmt := range stmts {
-		stmt := strings.TrimSpace(stmt)
-		if stmt == "" {
+	s := newSchemaParserSource()
+	for _, fpath := range files {
+		b, err := os.ReadFile(fpath)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := s.parse(fpath, string(b)); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.validate(); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+func newSchemaParserSource() *schemaParserSource {
+	return &schemaParserSource{
+		tables:     make(map[string]table),
+		positions:  make(map[ast.Node]string),
+		indexTypes: make(map[*ast.CreateIndex]string),
+	}
+}
+
+// parse parses the DDL statements in buffer and applies them to the schema.
+// fpath is used for the positions in error messages.
+func (s *schemaParserSource) parse(fpath, buffer string) error {
+	file := &token.File{FilePath: fpath, Buffer: buffer}
+	ddls, err := (&memefish.Parser{
+		Lexer: &memefish.Lexer{File: file},
+	}).ParseDDLs()
+	if err != nil {
+		return err
+	}
+
+	for _, ddl := range ddls {
+		s.recordPositions(file, ddl)
+		if err := s.apply(ddl); err != nil {
+			return fmt.Errorf("%s: %v", s.positions[ddl], err)
+		}
+	}
+
+	return nil
+}
+
+// recordPositions records the positions of the statement and the nodes in it
+// which are referred by error messages.
+func (s *schemaParserSource) recordPositions(file *token.File, ddl ast.DDL) {
+	record := func(node ast.Node) {
+		line, column := file.ResolvePos(node.Pos())
+		s.positions[node] = fmt.Sprintf("%s:%d:%d", file.FilePath, line+1, column+1)
+	}
+
+	record(ddl)
+	switch val := ddl.(type) {
+	case *ast.CreateTable:
+		for _, c := range val.Columns {
+			record(c)
+		}
+	case *ast.AlterTable:
+		switch alt := val.TableAlteration.(type) {
+		case *ast.AddColumn:
+			record(alt.Column)
+		}
+	}
+}
+
+// validate reports indexes on undefined tables.
+func (s *schemaParserSource) validate() error {
+	names := make([]string, 0, len(s.tables))
+	for name := range s.tables {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		t := s.tables[name]
+		if t.createTable != nil || t.createView != nil || len(t.createIndexes) == 0 {
 			continue
 		}
+		ix := t.createIndexes[0]
+		return fmt.Errorf("%s: index %s is on an undefined table %s", s.positions[ix], ix.Name.SQL(), name)
+	}
 
-		ddlstmt, err := memefish.ParseDDL("", stmt)
+	return nil
+}
+
+// Position returns the position of the table or the column in the DDL files
+// as file:line:column.
+func (s *schemaParserSource) Position(table, column string) string {
+	t := s.tables[table]
+	switch {
+	case t.createView != nil:
+		return s.positions[t.createView]
+	case t.createTable == nil:
+		return ""
+	case column == "":
+		return s.positions[t.createTable]
+	}
+
+	if i := findColumn(t.createTable.Columns, column); i >= 0 {
+		return s.positions[t.createTable.Columns[i]]
+	}
+	return s.positions[t.createTable]
+}
+
+// ddlFiles returns the DDL files specified by fpath in the order to be
+// applied. A directory is expanded to the .sql files in it.
+func ddlFiles(fpath string) ([]string, error) {
+	fi, err := os.Stat(fpath)
+	switch {
+	case err == nil && fi.IsDir():
+		entries, err := os.ReadDir(fpath)
 		if err != nil {
 			return nil, err
 		}
 
-		switch val := ddlstmt.(type) {
-		case *ast.CreateTable:
-			tableName, err := extractName(val.Name)
-			if err != nil {
-				return nil, err
+		var files []string
+		for _, e := range entries {
+			if !e.IsDir() && filepath.Ext(e.Name()) == ".sql" {
+				files = append(files, filepath.Join(fpath, e.Name()))
+			}
+		}
+		if len(files) == 0 {
+			return nil, fmt.Errorf("no DDL files found in %s", fpath)
+		}
+		return files, nil
+	case err == nil:
+		return []string{fpath}, nil
+	case !os.IsNotExist(err):
+		return nil, err
+	}
+
+	files, globErr := filepath.Glob(fpath)
+	if globErr != nil {
+		return nil, globErr
+	}
+	if len(files) == 0 {
+		// report the original error if fpath is not a pattern
+		return nil, err
+	}
+	sort.Strings(files)
+
+	return files, nil
+}
+
+// apply applies the DDL statement to the schema.
+func (s *schemaParserSource) apply(stmt ast.DDL) error {
+	switch val := stmt.(type) {
+	case *ast.CreateTable:
+		tableName, err := extractName(val.Name)
+		if err != nil {
+			return err
+		}
+
+		v := s.tables[tableName]
+		if v.createTable != nil {
+			if val.IfNotExists {
+				return nil
 			}
+			return fmt.Errorf("table %s already exists", tableName)
+		}
+		v.createTable = val
+		s.tables[tableName] = v
+	case *ast.CreateView:
+		viewName, err := extractName(val.Name)
+		if err != nil {
+			return err
+		}
 
-			v := tables[tableName]
-			v.createTable = val
-			tables[tableName] = v
-		case *ast.CreateIndex:
-			tableName, err := extractName(val.TableName)
-			if err != nil {
-				return nil, err
+		v := s.tables[viewName]
+		if v.createView != nil && !val.OrReplace {
+			return fmt.Errorf("view %s already exists", viewName)
+		}
+		v.createView = val
+		s.tables[viewName] = v
+	case *ast.CreateChangeStream:
+		s.changeStreams = append(s.changeStreams, val)
+	case *ast.CreateIndex:
+		return s.addIndex(val, models.IndexTypeIndex)
+	case *ast.CreateSearchIndex:
+		// a search index is held as an index with the TOKENLIST columns as the keys
+		ix := &ast.CreateIndex{
+			Name:         &ast.Path{Idents: []*ast.Ident{val.Name}},
+			TableName:    &ast.Path{Idents: []*ast.Ident{val.TableName}},
+			Storing:      val.Storing,
+			InterleaveIn: val.Interleave,
+		}
+		for _, c := range val.TokenListPart {
+			ix.Keys = append(ix.Keys, &ast.IndexKey{Name: c})
+		}
+		s.positions[ix] = s.positions[val]
+		return s.addIndex(ix, models.IndexTypeSearch)
+	case *ast.CreateVectorIndex:
+		ix := &ast.CreateIndex{
+			IfNotExists: val.IfNotExists,
+			Name:        &ast.Path{Idents: []*ast.Ident{val.Name}},
+			TableName:   &ast.Path{Idents: []*ast.Ident{val.TableName}},
+			Keys:        []*ast.IndexKey{{Name: val.ColumnName}},
+			Storing:     val.Storing,
+		}
+		s.positions[ix] = s.positions[val]
+		return s.addIndex(ix, models.IndexTypeVector)
+	case *ast.AlterTable:
+		return s.alterTable(val)
+	case *ast.AlterIndex:
+		indexName, err := extractName(val.Name)
+		if err != nil {
+			return err
+		}
+		return s.alterIndex(indexName, val.IndexAlteration)
+	case *ast.AlterSearchIndex:
+		return s.alterIndex(val.Name.Name, val.IndexAlteration)
+	case *ast.AlterVectorIndex:
+		indexName, err := extractName(val.Name)
+		if err != nil {
+			return err
+		}
+		return s.alterIndex(indexName, val.Alteration)
+	case *ast.AlterChangeStream:
+		return s.alterChangeStream(val)
+	case *ast.RenameTable:
+		for _, to := range val.Tos {
+			if err := s.renameTable(to.Old.Name, to.New.Name); err != nil {
+				return err
 			}
+		}
+	case *ast.DropTable:
+		tableName, err := extractName(val.Name)
+		if err != nil {
+			return err
+		}
 
-			v := tables[tableName]
-			v.createIndexes = append(v.createIndexes, val)
-			tables[tableName] = v
-		case *ast.AlterTable:
-			if isAlterTableAddFK(val) {
-				continue
+		if s.tables[tableName].createTable == nil {
+			if val.IfExists {
+				return nil
 			}
-			return nil, fmt.Errorf("unknown statement is specified: %s", ddlstmt.SQL())
+			return fmt.Errorf("table %s is not found", tableName)
+		}
+		delete(s.tables, tableName)
+	case *ast.DropView:
+		viewName, err := extractName(val.Name)
+		if err != nil {
+			return err
 		}
+
+		if s.tables[viewName].createView == nil {
+			return fmt.Errorf("view %s is not found", viewName)
+		}
+		delete(s.tables, viewName)
+	case *ast.DropIndex:
+		indexName, err := extractName(val.Name)
+		if err != nil {
+			return err
+		}
+		return s.dropIndex(indexName, val.IfExists)
+	case *ast.DropSearchIndex:
+		return s.dropIndex(val.Name.Name, val.IfExists)
+	case *ast.DropVectorIndex:
+		return s.dropIndex(val.Name.Name, val.IfExists)
+	case *ast.DropChangeStream:
+		i, ok := s.findChangeStream(val.Name.Name)
+		if !ok {
+			return fmt.Errorf("change stream %s is not found", val.Name.Name)
+		}
+		s.changeStreams = append(s.changeStreams[:i:i], s.changeStreams[i+1:]...)
+	case *ast.CreateSchema, *ast.DropSchema, *ast.CreateDatabase, *ast.AlterDatabase,
+		*ast.CreateLocalityGroup, *ast.AlterLocalityGroup, *ast.DropLocalityGroup, *ast.CreatePlacement,
+		*ast.CreateProtoBundle, *ast.AlterProtoBundle, *ast.DropProtoBundle,
+		*ast.CreateRole, *ast.DropRole, *ast.Grant, *ast.Revoke,
+		*ast.CreateSequence, *ast.AlterSequence, *ast.DropSequence,
+		*ast.CreateModel, *ast.AlterModel, *ast.DropModel,
+		*ast.CreatePropertyGraph, *ast.DropPropertyGraph, *ast.AlterStatistics, *ast.Analyze:
+		// the statements do not change the tables
+	default:
+		return fmt.Errorf("unknown statement is specified: %s", stmt.SQL())
 	}
 
-	return &schemaParserSource{tables: tables}, nil
+	return nil
 }
 
-func isAlterTableAddFK(at *ast.AlterTable) bool {
+func (s *schemaParserSource) alterTable(at *ast.AlterTable) error {
+	tableName, err := extractName(at.Name)
+	if err != nil {
+		return err
+	}
+
+	if isAlterTableAddConstraint(at) {
+		// a constraint can be added before the table is created
+		v := s.tables[tableName]
+		v.constraints = append(v.constraints, at.TableAlteration.(*ast.AddTableConstraint).TableConstraint)
+		s.tables[tableName] = v
+		return nil
+	}
+
+	v := s.tables[tableName]
+	ct := v.createTable
+	if ct == nil {
+		return fmt.Errorf("table %s is not found: %s", tableName, at.SQL())
+	}
+
+	switch alt := at.TableAlteration.(type) {
+	case *ast.AddColumn:
+		if findColumn(ct.Columns, alt.Column.Name.Name) >= 0 {
+			if alt.IfNotExists {
+				return nil
+			}
+			return fmt.Errorf("column %s already exists in the table %s", alt.Column.Name.Name, tableName)
+		}
+		ct.Columns = append(ct.Columns, alt.Column)
+	case *ast.DropColumn:
+		i := findColumn(ct.Columns, alt.Name.Name)
+		if i < 0 {
+			return fmt.Errorf("column %s is not found in the table %s", alt.Name.Name, tableName)
+		}
+		ct.Columns = append(ct.Columns[:i:i], ct.Columns[i+1:]...)
+	case *ast.AlterColumn:
+		i := findColumn(ct.Columns, alt.Name.Name)
+		if i < 0 {
+			return fmt.Errorf("column %s is not found in the table %s", alt.Name.Name, tableName)
+		}
+		alterColumn(ct.Columns[i], alt.Alteration)
+	case *ast.DropConstraint:
+		name := alt.Name.Name
+		n := len(ct.TableConstraints) + len(v.constraints)
+		ct.TableConstraints = dropConstraint(ct.TableConstraints, name)
+		v.constraints = dropConstraint(v.constraints, name)
+		if len(ct.TableConstraints)+len(v.constraints) == n {
+			return fmt.Errorf("constraint %s is not found in the table %s", name, tableName)
+		}
+		s.tables[tableName] = v
+	case *ast.SetOnDelete:
+		if ct.Cluster == nil {
+			return fmt.Errorf("table %s is not interleaved", tableName)
+		}
+		ct.Cluster.OnDelete = alt.OnDelete
+	case *ast.SetInterleaveIn:
+		ct.Cluster = &ast.Cluster{
+			TableName: alt.TableName,
+			Enforced:  alt.Enforced,
+			OnDelete:  alt.OnDelete,
+		}
+	case *ast.RenameTo:
+		newName := alt.Name.Name
+		if schema, _ := splitQualifiedName(tableName); schema != "" {
+			newName = qualifiedName(schema, newName)
+		}
+		return s.renameTable(tableName, newName)
+	case *ast.AddRowDeletionPolicy:
+		ct.RowDeletionPolicy = &ast.CreateRowDeletionPolicy{RowDeletionPolicy: alt.RowDeletionPolicy}
+	case *ast.ReplaceRowDeletionPolicy:
+		ct.RowDeletionPolicy = &ast.CreateRowDeletionPolicy{RowDeletionPolicy: alt.RowDeletionPolicy}
+	case *ast.DropRowDeletionPolicy:
+		ct.RowDeletionPolicy = nil
+	case *ast.AddSynonym, *ast.DropSynonym, *ast.AlterTableSetOptions:
+		// these don't affect the generated code
+	default:
+		return fmt.Errorf("unknown statement is specified: %s", at.SQL())
+	}
+
+	return nil
+}
+
+func isAlterTableAddConstraint(at *ast.AlterTable) bool {
 	ac, ok := at.TableAlteration.(*ast.AddTableConstraint)
 	if !ok {
 		return false
 	}
-	_, ok = ac.TableConstraint.Constraint.(*ast.ForeignKey)
-	return ok
+	switch ac.TableConstraint.Constraint.(type) {
+	case *ast.ForeignKey, *ast.Check:
+		return true
+	default:
+		return false
+	}
+}
+
+// alterColumn applies ALTER COLUMN to the column definition.
+func alterColumn(col *ast.ColumnDef, alt ast.ColumnAlteration) {
+	switch a := alt.(type) {
+	case *ast.AlterColumnType:
+		col.Type = a.Type
+		col.NotNull = a.NotNull
+		if _, ok := col.DefaultSemantics.(*ast.GeneratedColumnExpr); !ok {
+			// the default value is dropped unless it is specified again
+			col.DefaultSemantics = nil
+			if a.DefaultExpr != nil {
+				col.DefaultSemantics = a.DefaultExpr
+			}
+		}
+	case *ast.AlterColumnSetOptions:
+		col.Options = mergeOptions(col.Options, a.Options)
+	case *ast.AlterColumnSetDefault:
+		col.DefaultSemantics = a.DefaultExpr
+	case *ast.AlterColumnDropDefault:
+		col.DefaultSemantics = nil
+	}
+}
+
+// addIndex adds the index of the type to the table.
+func (s *schemaParserSource) addIndex(ix *ast.CreateIndex, indexType string) error {
+	tableName, err := extractName(ix.TableName)
+	if err != nil {
+		return err
+	}
+
+	if ix.IfNotExists {
+		indexName, err := extractName(ix.Name)
+		if err != nil {
+			return err
+		}
+		if _, _, ok := s.findIndex(indexName); ok {
+			return nil
+		}
+	}
+
+	v := s.tables[tableName]
+	v.createIndexes = append(v.createIndexes, ix)
+	s.tables[tableName] = v
+	if indexType != models.IndexTypeIndex {
+		s.indexTypes[ix] = indexType
+	}
+
+	return nil
+}
+
+func (s *schemaParserSource) dropIndex(indexName string, ifExists bool) error {
+	tableName, i, ok := s.findIndex(indexName)
+	if !ok {
+		if ifExists {
+			return nil
+		}
+		return fmt.Errorf("index %s is not found", indexName)
+	}
+
+	v := s.tables[tableName]
+	delete(s.indexTypes, v.createIndexes[i])
+	v.createIndexes = append(v.createIndexes[:i:i], v.createIndexes[i+1:]...)
+	s.tables[tableName] = v
+
+	return nil
+}
+
+// alterIndex applies ADD or DROP STORED COLUMN to the index.
+func (s *schemaParserSource) alterIndex(indexName string, alteration ast.Node) error {
+	tableName, i, ok := s.findIndex(indexName)
+	if !ok {
+		return fmt.Errorf("index %s is not found", indexName)
+	}
+	index := s.tables[tableName].createIndexes[i]
+
+	switch alt := alteration.(type) {
+	case *ast.AddStoredColumn:
+		if index.Storing == nil {
+			index.Storing = &ast.Storing{}
+		}
+		index.Storing.Columns = append(index.Storing.Columns, alt.Name)
+	case *ast.DropStoredColumn:
+		if index.Storing == nil {
+			return fmt.Errorf("column %s is not stored in the index %s", alt.Name.Name, indexName)
+		}
+		cols := index.Storing.Columns
+		j := findIdent(cols, alt.Name.Name)
+		if j < 0 {
+			return fmt.Errorf("column %s is not stored in the index %s", alt.Name.Name, indexName)
+		}
+		index.Storing.Columns = append(cols[:j:j], cols[j+1:]...)
+		if len(index.Storing.Columns) == 0 {
+			index.Storing = nil
+		}
+	}
+
+	return nil
+}
+
+func (s *schemaParserSource) alterChangeStream(acs *ast.AlterChangeStream) error {
+	i, ok := s.findChangeStream(acs.Name.Name)
+	if !ok {
+		return fmt.Errorf("change stream %s is not found", acs.Name.Name)
+	}
+	cs := s.changeStreams[i]
+
+	switch alt := acs.ChangeStreamAlteration.(type) {
+	case *ast.ChangeStreamSetFor:
+		cs.For = alt.For
+	case *ast.ChangeStreamDropForAll:
+		cs.For = nil
+	case *ast.ChangeStreamSetOptions:
+		cs.Options = mergeOptions(cs.Options, alt.Options)
+	}
+
+	return nil
+}
+
+// renameTable renames the table and updates the references to it from the
+// other tables and change streams.
+func (s *schemaParserSource) renameTable(oldName, newName string) error {
+	v := s.tables[oldName]
+	if v.createTable == nil {
+		return fmt.Errorf("table %s is not found", oldName)
+	}
+	if _, ok := s.tables[newName]; ok {
+		return fmt.Errorf("table %s already exists", newName)
+	}
+
+	delete(s.tables, oldName)
+	s.tables[newName] = v
+
+	schema, oldTable := splitQualifiedName(oldName)
+	_, newTable := splitQualifiedName(newName)
+	rename := func(p *ast.Path) *ast.Path {
+		name, err := extractName(p)
+		if err != nil {
+			return p
+		}
+		if name != oldName && !(len(p.Idents) == 1 && qualifiedName(schema, name) == oldName) {
+			return p
+		}
+		idents := append([]*ast.Ident(nil), p.Idents...)
+		idents[len(idents)-1] = &ast.Ident{Name: newTable}
+		return &ast.Path{Idents: idents}
+	}
+
+	v.createTable.Name = rename(v.createTable.Name)
+	for _, t := range s.tables {
+		if t.createTable == nil {
+			continue
+		}
+		if t.createTable.Cluster != nil {
+			t.createTable.Cluster.TableName = rename(t.createTable.Cluster.TableName)
+		}
+		for _, constraints := range [][]*ast.TableConstraint{t.createTable.TableConstraints, t.constraints} {
+			for _, tc := range constraints {
+				if fk, ok := tc.Constraint.(*ast.ForeignKey); ok {
+					fk.ReferenceTable = rename(fk.ReferenceTable)
+				}
+			}
+		}
+		for _, ix := range t.createIndexes {
+			ix.TableName = rename(ix.TableName)
+		}
+	}
+	for _, cs := range s.changeStreams {
+		if f, ok := cs.For.(*ast.ChangeStreamForTables); ok {
+			for _, t := range f.Tables {
+				if t.TableName.Name == oldTable {
+					t.TableName = &ast.Ident{Name: newTable}
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// findIndex returns the table name and the position of the index.
+func (s *schemaParserSource) findIndex(name string) (string, int, bool) {
+	for tableName, t := range s.tables {
+		for i, ix := range t.createIndexes {
+			if n, err := extractName(ix.Name); err == nil && n == name {
+				return tableName, i, true
+			}
+		}
+	}
+	return "", 0, false
+}
+
+func (s *schemaParserSource) findChangeStream(name string) (int, bool) {
+	for i, cs := range s.changeStreams {
+		if cs.Name.Name == name {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+func findColumn(cols []*ast.ColumnDef, name string) int {
+	for i, c := range cols {
+		if c.Name.Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+func findIdent(idents []*ast.Ident, name string) int {
+	for i, id := range idents {
+		if id.Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// dropConstraint returns the constraints without the named one.
+func dropConstraint(constraints []*ast.TableConstraint, name string) []*ast.TableConstraint {
+	var res []*ast.TableConstraint
+	for _, tc := range constraints {
+		if tc.Name != nil && tc.Name.Name == name {
+			continue
+		}
+		res = append(res, tc)
+	}
+	return res
+}
+
+// mergeOptions returns the options with the values set by SET OPTIONS. A
+// NULL value resets the option.
+func mergeOptions(opts, set *ast.Options) *ast.Options {
+	var records []*ast.OptionsDef
+	if opts != nil {
+		records = append(records, opts.Records...)
+	}
+
+	for _, o := range set.Records {
+		i := 0
+		for ; i < len(records); i++ {
+			if strings.EqualFold(records[i].Name.Name, o.Name.Name) {
+				break
+			}
+		}
+		if _, ok := o.Value.(*ast.NullLiteral); ok {
+			if i < len(records) {
+				records = append(records[:i:i], records[i+1:]...)
+			}
+			continue
+		}
+		if i < len(records) {
+			records[i] = o
+		} else {
+			records = append(records, o)
+		}
+	}
+
+	if len(records) == 0 {
+		return nil
+	}
+	return &ast.Options{Records: records}
+}
+
+// onDeleteAction returns the action name of ON DELETE clause in the same
+// form as INFORMATION_SCHEMA. The default action is NO ACTION.
+func onDeleteAction(action ast.OnDeleteAction) string {
+	if action == "" {
+		return "NO ACTION"
+	}
+	return strings.TrimPrefix(string(action), "ON DELETE ")
 }
 
 type table struct {
 	createTable   *ast.CreateTable
+	createView    *ast.CreateView
 	createIndexes []*ast.CreateIndex
+	constraints   []*ast.TableConstraint // added by ALTER TABLE
 }
 
 type schemaParserSource struct {
-	tables map[string]table
+	tables        map[string]table
+	changeStreams []*ast.CreateChangeStream
+	positions     map[ast.Node]string         // file:line:column of the nodes
+	indexTypes    map[*ast.CreateIndex]string // type of a search or vector index held as an index
+}
+
+// Dialect returns GoogleSQL since the DDL is parsed as GoogleSQL.
+func (s *schemaParserSource) Dialect() (string, error) {
+	return models.DialectGoogleSQL, nil
 }
 
 func (s *schemaParserSource) TableList() ([]*SpannerTable, error) {
 	var tables []*SpannerTable
-	for _, t := range s.tables {
-		var parent string
-		if t.createTable.Cluster != nil {
-			var err error
-			parent, err = extractName(t.createTable.Cluster.TableName)
+	for name, t := range s.tables {
+		if t.createView != nil {
+			schema, viewName := splitQualifiedName(name)
+			tables = append(tables, &SpannerTable{
+				TableSchema: schema,
+				TableName:   viewName,
+				IsView:      true,
+			})
+			continue
+		}
+		if t.createTable == nil {
+			// ALTER TABLE for an unknown table
+			continue
+		}
+
+		var parent, onDelete, interleaveType string
+		if cluster := t.createTable.Cluster; cluster != nil {
+			name, err := extractName(cluster.TableName)
 			if err != nil {
 				return nil, err
 			}
+			// a parent table always belongs to the same schema
+			_, parent = splitQualifiedName(name)
+
+			interleaveType = "IN"
+			if cluster.Enforced {
+				interleaveType = "IN PARENT"
+				onDelete = onDeleteAction(cluster.OnDelete)
+			}
 		}
-		tableName, err := extractName(t.createTable.Name)
+		name, err := extractName(t.createTable.Name)
 		if err != nil {
 			return nil, err
 		}
+		schema, tableName := splitQualifiedName(name)
+
+		var rowDeletionPolicy string
+		if rdp := t.createTable.RowDeletionPolicy; rdp != nil {
+			// same as ROW_DELETION_POLICY_EXPRESSION of INFORMATION_SCHEMA.TABLES
+			rowDeletionPolicy = fmt.Sprintf("OLDER_THAN(%s, INTERVAL %s DAY)",
+				rdp.RowDeletionPolicy.ColumnName.Name, rdp.RowDeletionPolicy.NumDays.Value)
+		}
 
 		tables = append(tables, &SpannerTable{
-			TableName:       tableName,
-			ParentTableName: parent,
+			TableSchema:       schema,
+			TableName:         tableName,
+			ParentTableName:   parent,
+			OnDeleteAction:    onDelete,
+			InterleaveType:    interleaveType,
+			RowDeletionPolicy: rowDeletionPolicy,
 		})
 	}
 
 	sort.Slice(tables, func(i, j int) bool {
+		if tables[i].TableSchema != tables[j].TableSchema {
+			return tables[i].TableSchema < tables[j].TableSchema
+		}
 		return tables[i].TableName < tables[j].TableName
 	})
 
@@ -134,6 +792,14 @@ func (s *schemaParserSource) TableList() ([]*SpannerTable, error) {
 }
 
 func (s *schemaParserSource) ColumnList(name string) ([]*SpannerColumn, error) {
+	if view := s.tables[name].createView; view != nil {
+		cols, err := s.viewColumnList(name, map[string]bool{})
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", s.positions[view], err)
+		}
+		return cols, nil
+	}
+
 	var cols []*SpannerColumn
 	table := s.tables[name].createTable
 
@@ -145,8 +811,14 @@ func (s *schemaParserSource) ColumnList(name string) ([]*SpannerColumn, error) {
 	for i, c := range table.Columns {
 		_, pk := check[c.Name.Name]
 		isGenerated := false
-		if _, ok := c.DefaultSemantics.(*ast.GeneratedColumnExpr); ok {
+		var hasDefault bool
+		var defaultExpr string
+		switch d := c.DefaultSemantics.(type) {
+		case *ast.GeneratedColumnExpr:
 			isGenerated = true
+		case *ast.ColumnDefaultExpr:
+			hasDefault = true
+			defaultExpr = d.Expr.SQL()
 		}
 		cols = append(cols, &SpannerColumn{
 			FieldOrdinal: i + 1,
@@ -156,23 +828,198 @@ func (s *schemaParserSource) ColumnList(name string) ([]*SpannerColumn, error) {
 			IsPrimaryKey: pk,
 			IsGenerated:  isGenerated,
 			IsHidden:     c.Hidden != token.InvalidPos,
+			HasDefault:   hasDefault,
+			DefaultExpr:  defaultExpr,
+
+			AllowCommitTimestamp: allowCommitTimestamp(c.Options),
 		})
 	}
 
 	return cols, nil
 }
 
+// allowCommitTimestamp returns whether allow_commit_timestamp is true in the column options.
+func allowCommitTimestamp(opts *ast.Options) bool {
+	if opts == nil {
+		return false
+	}
+
+	for _, o := range opts.Records {
+		if strings.EqualFold(o.Name.Name, "allow_commit_timestamp") {
+			return optionValue(o.Value) == "TRUE"
+		}
+	}
+
+	return false
+}
+
+func (s *schemaParserSource) ForeignKeyList(name string) ([]*SpannerForeignKey, error) {
+	tbl, ok := s.tables[name]
+	if !ok || tbl.createTable == nil {
+		return nil, nil
+	}
+
+	schema, _ := splitQualifiedName(name)
+	var constraints []*ast.TableConstraint
+	constraints = append(constraints, tbl.createTable.TableConstraints...)
+	constraints = append(constraints, tbl.constraints...)
+
+	var fks []*SpannerForeignKey
+	for _, tc := range constraints {
+		fk, ok := tc.Constraint.(*ast.ForeignKey)
+		if !ok {
+			continue
+		}
+
+		refName, err := extractName(fk.ReferenceTable)
+		if err != nil {
+			return nil, err
+		}
+		refSchema, refTable := splitQualifiedName(refName)
+		if len(fk.ReferenceTable.Idents) == 1 {
+			// an unqualified name refers to a table in the same schema
+			refSchema = schema
+		}
+
+		var constraintName string
+		if tc.Name != nil {
+			constraintName = tc.Name.Name
+		}
+
+		var cols, refCols []string
+		for _, c := range fk.Columns {
+			cols = append(cols, c.Name)
+		}
+		for _, c := range fk.ReferenceColumns {
+			refCols = append(refCols, c.Name)
+		}
+
+		fks = append(fks, &SpannerForeignKey{
+			ConstraintName: constraintName,
+			ColumnNames:    cols,
+			RefTableSchema: refSchema,
+			RefTableName:   refTable,
+			RefColumnNames: refCols,
+			OnDeleteAction: onDeleteAction(fk.OnDelete),
+		})
+	}
+
+	return fks, nil
+}
+
+func (s *schemaParserSource) CheckConstraintList(name string) ([]*SpannerCheckConstraint, error) {
+	tbl, ok := s.tables[name]
+	if !ok || tbl.createTable == nil {
+		return nil, nil
+	}
+
+	var constraints []*ast.TableConstraint
+	constraints = append(constraints, tbl.createTable.TableConstraints...)
+	constraints = append(constraints, tbl.constraints...)
+
+	var checks []*SpannerCheckConstraint
+	for _, tc := range constraints {
+		check, ok := tc.Constraint.(*ast.Check)
+		if !ok {
+			continue
+		}
+
+		var constraintName string
+		if tc.Name != nil {
+			constraintName = tc.Name.Name
+		}
+
+		checks = append(checks, &SpannerCheckConstraint{
+			ConstraintName: constraintName,
+			CheckClause:    check.Expr.SQL(),
+		})
+	}
+
+	return checks, nil
+}
+
+func (s *schemaParserSource) ChangeStreamList() ([]*SpannerChangeStream, error) {
+	var streams []*SpannerChangeStream
+	for _, cs := range s.changeStreams {
+		stream := &SpannerChangeStream{
+			ChangeStreamName: cs.Name.Name,
+			Options:          make(map[string]string),
+		}
+
+		switch f := cs.For.(type) {
+		case *ast.ChangeStreamForAll:
+			stream.All = true
+		case *ast.ChangeStreamForTables:
+			for _, t := range f.Tables {
+				// FOR table without the column list watches all columns
+				tbl := &SpannerChangeStreamTable{
+					TableName:  t.TableName.Name,
+					AllColumns: t.Rparen == token.InvalidPos,
+				}
+				for _, c := range t.Columns {
+					tbl.ColumnNames = append(tbl.ColumnNames, c.Name)
+				}
+				stream.Tables = append(stream.Tables, tbl)
+			}
+		}
+
+		if cs.Options != nil {
+			for _, o := range cs.Options.Records {
+				stream.Options[o.Name.Name] = optionValue(o.Value)
+			}
+		}
+
+		streams = append(streams, stream)
+	}
+
+	sort.Slice(streams, func(i, j int) bool {
+		return streams[i].ChangeStreamName < streams[j].ChangeStreamName
+	})
+
+	return streams, nil
+}
+
+// optionValue returns the value of OPTIONS in the same form as
+// INFORMATION_SCHEMA.
+func optionValue(expr ast.Expr) string {
+	switch e := expr.(type) {
+	case *ast.StringLiteral:
+		return e.Value
+	case *ast.BoolLiteral:
+		if e.Value {
+			return "TRUE"
+		}
+		return "FALSE"
+	default:
+		return expr.SQL()
+	}
+}
+
 func (s *schemaParserSource) IndexList(name string) ([]*SpannerIndex, error) {
 	var indexes []*SpannerIndex
 	for _, index := range s.tables[name].createIndexes {
-		indexName, err := extractName(index.Name)
+		name, err := extractName(index.Name)
 		if err != nil {
 			return nil, err
 		}
+		_, indexName := splitQualifiedName(name)
+
+		var parent string
+		if index.InterleaveIn != nil {
+			parent = index.InterleaveIn.TableName.Name
+		}
+
+		indexType := models.IndexTypeIndex
+		if t, ok := s.indexTypes[index]; ok {
+			indexType = t
+		}
 
 		indexes = append(indexes, &SpannerIndex{
-			IndexName: indexName,
-			IsUnique:  index.Unique,
+			IndexName:       indexName,
+			IsUnique:        index.Unique,
+			IsNullFiltered:  index.NullFiltered,
+			ParentTableName: parent,
+			IndexType:       indexType,
 		})
 	}
 
@@ -186,11 +1033,11 @@ func (s *schemaParserSource) IndexColumnList(table, index string) ([]*SpannerInd
 
 	var cols []*SpannerIndexColumn
 	for _, ix := range s.tables[table].createIndexes {
-		ixName, err := extractName(ix.Name)
+		name, err := extractName(ix.Name)
 		if err != nil {
 			return nil, err
 		}
-		if ixName != index {
+		if _, ixName := splitQualifiedName(name); ixName != index {
 			continue
 		}
 
@@ -209,6 +1056,7 @@ func (s *schemaParserSource) IndexColumnList(table, index string) ([]*SpannerInd
 			cols = append(cols, &SpannerIndexColumn{
 				SeqNo:      i + 1,
 				ColumnName: c.Name.Name,
+				Desc:       c.Dir == ast.DirectionDesc,
 			})
 		}
 		break
@@ -219,7 +1067,7 @@ func (s *schemaParserSource) IndexColumnList(table, index string) ([]*SpannerInd
 
 func (s *schemaParserSource) primaryKeyColumnList(table string) ([]*SpannerIndexColumn, error) {
 	tbl, ok := s.tables[table]
-	if !ok {
+	if !ok || tbl.createTable == nil {
 		return nil, nil
 	}
 
@@ -228,8 +1076,240 @@ func (s *schemaParserSource) primaryKeyColumnList(table string) ([]*SpannerIndex
 		cols = append(cols, &SpannerIndexColumn{
 			SeqNo:      i + 1,
 			ColumnName: key.Name.Name,
+			Desc:       key.Dir == ast.DirectionDesc,
 		})
 	}
 
 	return cols, nil
 }
+
+// viewColumnList infers the columns of the view from its query. The type of
+// a column is determined by the referenced column, CAST or literal since
+// there is no type analysis. All columns of a view are nullable.
+func (s *schemaParserSource) viewColumnList(name string, visited map[string]bool) ([]*SpannerColumn, error) {
+	if visited[name] {
+		return nil, fmt.Errorf("view %s references itself", name)
+	}
+	visited[name] = true
+	defer delete(visited, name)
+
+	view := s.tables[name].createView
+
+	query := view.Query
+	if q, ok := query.(*ast.Query); ok {
+		query = q.Query
+	}
+	sel, ok := query.(*ast.Select)
+	if !ok {
+		return nil, fmt.Errorf("unsupported query in view %s: %s", name, query.SQL())
+	}
+
+	var sources []*viewSource
+	if sel.From != nil {
+		var err error
+		sources, err = s.viewSources(sel.From.Source, visited)
+		if err != nil {
+			return nil, fmt.Errorf("view %s: %v", name, err)
+		}
+	}
+
+	var cols []*SpannerColumn
+	add := func(name, dataType string) {
+		cols = append(cols, &SpannerColumn{
+			FieldOrdinal: len(cols) + 1,
+			ColumnName:   name,
+			DataType:     dataType,
+		})
+	}
+
+	for _, item := range sel.Results {
+		switch item := item.(type) {
+		case *ast.Star:
+			if item.Replace != nil {
+				return nil, fmt.Errorf("view %s: unsupported select item: %s", name, item.SQL())
+			}
+			for _, src := range sources {
+				for _, c := range src.columns {
+					if !c.IsHidden && !isExcepted(item.Except, c.ColumnName) {
+						add(c.ColumnName, c.DataType)
+					}
+				}
+			}
+		case *ast.DotStar:
+			ident, ok := item.Expr.(*ast.Ident)
+			if !ok || item.Replace != nil {
+				return nil, fmt.Errorf("view %s: unsupported select item: %s", name, item.SQL())
+			}
+			src := findViewSource(sources, ident.Name)
+			if src == nil {
+				return nil, fmt.Errorf("view %s: unknown table %s", name, ident.Name)
+			}
+			for _, c := range src.columns {
+				if !c.IsHidden && !isExcepted(item.Except, c.ColumnName) {
+					add(c.ColumnName, c.DataType)
+				}
+			}
+		case *ast.ExprSelectItem:
+			var colName string
+			switch e := item.Expr.(type) {
+			case *ast.Ident:
+				colName = e.Name
+			case *ast.Path:
+				colName = e.Idents[len(e.Idents)-1].Name
+			default:
+				return nil, fmt.Errorf("view %s: column name of %s is not determined, use an alias", name, item.SQL())
+			}
+			dataType, err := viewExprType(item.Expr, sources)
+			if err != nil {
+				return nil, fmt.Errorf("view %s: %v", name, err)
+			}
+			add(colName, dataType)
+		case *ast.Alias:
+			dataType, err := viewExprType(item.Expr, sources)
+			if err != nil {
+				return nil, fmt.Errorf("view %s: %v", name, err)
+			}
+			add(item.As.Alias.Name, dataType)
+		default:
+			return nil, fmt.Errorf("view %s: unsupported select item: %s", name, item.SQL())
+		}
+	}
+
+	return cols, nil
+}
+
+// viewSource is a table or a view in FROM clause of a view.
+type viewSource struct {
+	name    string // alias or table name
+	columns []*SpannerColumn
+}
+
+// viewSources returns the tables and views in FROM clause in order.
+func (s *schemaParserSource) viewSources(expr ast.TableExpr, visited map[string]bool) ([]*viewSource, error) {
+	var name, alias string
+	switch e := expr.(type) {
+	case *ast.TableName:
+		name, alias = e.Table.Name, e.Table.Name
+		if e.As != nil {
+			alias = e.As.Alias.Name
+		}
+	case *ast.PathTableExpr:
+		n, err := extractName(e.Path)
+		if err != nil {
+			return nil, err
+		}
+		name, alias = n, e.Path.Idents[len(e.Path.Idents)-1].Name
+		if e.As != nil {
+			alias = e.As.Alias.Name
+		}
+	case *ast.ParenTableExpr:
+		return s.viewSources(e.Source, visited)
+	case *ast.Join:
+		left, err := s.viewSources(e.Left, visited)
+		if err != nil {
+			return nil, err
+		}
+		right, err := s.viewSources(e.Right, visited)
+		if err != nil {
+			return nil, err
+		}
+		return append(left, right...), nil
+	default:
+		return nil, fmt.Errorf("unsupported table expression: %s", expr.SQL())
+	}
+
+	t, ok := s.tables[name]
+	var cols []*SpannerColumn
+	var err error
+	switch {
+	case ok && t.createView != nil:
+		cols, err = s.viewColumnList(name, visited)
+	case ok && t.createTable != nil:
+		cols, err = s.ColumnList(name)
+	default:
+		err = fmt.Errorf("unknown table %s", name)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return []*viewSource{{name: alias, columns: cols}}, nil
+}
+
+func findViewSource(sources []*viewSource, name string) *viewSource {
+	for _, src := range sources {
+		if src.name == name {
+			return src
+		}
+	}
+	return nil
+}
+
+func isExcepted(except *ast.StarModifierExcept, column string) bool {
+	if except == nil {
+		return false
+	}
+	for _, c := range except.Columns {
+		if c.Name == column {
+			return true
+		}
+	}
+	return false
+}
+
+// viewExprType returns the data type of the expression in a view.
+func viewExprType(expr ast.Expr, sources []*viewSource) (string, error) {
+	switch e := expr.(type) {
+	case *ast.Ident:
+		for _, src := range sources {
+			for _, c := range src.columns {
+				if c.ColumnName == e.Name {
+					return c.DataType, nil
+				}
+			}
+		}
+		return "", fmt.Errorf("unknown column %s", e.Name)
+	case *ast.Path:
+		if len(e.Idents) != 2 {
+			return "", fmt.Errorf("unsupported column reference %s", e.SQL())
+		}
+		src := findViewSource(sources, e.Idents[0].Name)
+		if src == nil {
+			return "", fmt.Errorf("unknown table %s", e.Idents[0].Name)
+		}
+		for _, c := range src.columns {
+			if c.ColumnName == e.Idents[1].Name {
+				return c.DataType, nil
+			}
+		}
+		return "", fmt.Errorf("unknown column %s", e.SQL())
+	case *ast.ParenExpr:
+		return viewExprType(e.Expr, sources)
+	case *ast.CastExpr:
+		return e.Type.SQL(), nil
+	case *ast.BoolLiteral:
+		return "BOOL", nil
+	case *ast.IntLiteral, *ast.CountStarExpr:
+		return "INT64", nil
+	case *ast.FloatLiteral:
+		return "FLOAT64", nil
+	case *ast.StringLiteral:
+		return "STRING(MAX)", nil
+	case *ast.BytesLiteral:
+		return "BYTES(MAX)", nil
+	case *ast.DateLiteral:
+		return "DATE", nil
+	case *ast.TimestampLiteral:
+		return "TIMESTAMP", nil
+	case *ast.NumericLiteral:
+		return "NUMERIC", nil
+	case *ast.JSONLiteral:
+		return "JSON", nil
+	case *ast.CallExpr:
+		if len(e.Func.Idents) == 1 && strings.EqualFold(e.Func.Idents[0].Name, "COUNT") {
+			return "INT64", nil
+		}
+	}
+
+	return "", fmt.Errorf("type of %s is not determined, use CAST", expr.SQL())
+}
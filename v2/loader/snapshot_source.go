@@ -0,0 +1,259 @@
+// Copyright (c) 2020 Mercari, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package loader
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v2"
+)
+
+// SnapshotVersion is the version of the snapshot format written by
+// DumpSnapshot. It is incremented on an incompatible change of the format.
+const SnapshotVersion = 1
+
+// Snapshot is a serializable copy of the schema information provided by a
+// SchemaSource. It allows generating code without access to the database.
+type Snapshot struct {
+	Version       int                    `json:"version" yaml:"version"`
+	Dialect       string                 `json:"dialect,omitempty" yaml:"dialect,omitempty"`
+	Tables        []*SnapshotTable       `json:"tables" yaml:"tables"`
+	ChangeStreams []*SpannerChangeStream `json:"changeStreams,omitempty" yaml:"changeStreams,omitempty"`
+}
+
+// SnapshotTable is a table or a view in a snapshot.
+type SnapshotTable struct {
+	SpannerTable     `yaml:",inline"`
+	Columns          []*SpannerColumn          `json:"columns" yaml:"columns"`
+	PrimaryKey       []*SpannerIndexColumn     `json:"primaryKey,omitempty" yaml:"primaryKey,omitempty"`
+	Indexes          []*SnapshotIndex          `json:"indexes,omitempty" yaml:"indexes,omitempty"`
+	ForeignKeys      []*SpannerForeignKey      `json:"foreignKeys,omitempty" yaml:"foreignKeys,omitempty"`
+	CheckConstraints []*SpannerCheckConstraint `json:"checkConstraints,omitempty" yaml:"checkConstraints,omitempty"`
+}
+
+// SnapshotIndex is an index in a snapshot.
+type SnapshotIndex struct {
+	SpannerIndex `yaml:",inline"`
+	Columns      []*SpannerIndexColumn `json:"columns" yaml:"columns"`
+}
+
+// DumpSnapshot reads all schema information from the source.
+func DumpSnapshot(source SchemaSource) (*Snapshot, error) {
+	dialect, err := source.Dialect()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load dialect: %v", err)
+	}
+
+	tables, err := source.TableList()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load tables: %v", err)
+	}
+
+	snapshot := &Snapshot{
+		Version: SnapshotVersion,
+		Dialect: dialect,
+		Tables:  []*SnapshotTable{},
+	}
+	for _, t := range tables {
+		name := qualifiedName(t.TableSchema, t.TableName)
+		table := &SnapshotTable{SpannerTable: *t}
+
+		if table.Columns, err = source.ColumnList(name); err != nil {
+			return nil, fmt.Errorf("failed to load columns of %s: %v", name, err)
+		}
+
+		if !t.IsView {
+			if table.PrimaryKey, err = source.IndexColumnList(name, "PRIMARY_KEY"); err != nil {
+				return nil, fmt.Errorf("failed to load primary key of %s: %v", name, err)
+			}
+			if table.ForeignKeys, err = source.ForeignKeyList(name); err != nil {
+				return nil, fmt.Errorf("failed to load foreign keys of %s: %v", name, err)
+			}
+			if table.CheckConstraints, err = source.CheckConstraintList(name); err != nil {
+				return nil, fmt.Errorf("failed to load check constraints of %s: %v", name, err)
+			}
+		}
+
+		indexes, err := source.IndexList(name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load indexes of %s: %v", name, err)
+		}
+		for _, ix := range indexes {
+			cols, err := source.IndexColumnList(name, ix.IndexName)
+			if err != nil {
+				return nil, fmt.Errorf("failed to load columns of index %s: %v", ix.IndexName, err)
+			}
+			table.Indexes = append(table.Indexes, &SnapshotIndex{SpannerIndex: *ix, Columns: cols})
+		}
+
+		snapshot.Tables = append(snapshot.Tables, table)
+	}
+
+	if snapshot.ChangeStreams, err = source.ChangeStreamList(); err != nil {
+		return nil, fmt.Errorf("failed to load change streams: %v", err)
+	}
+
+	return snapshot, nil
+}
+
+// Write writes the snapshot to w as YAML if the format is "yaml", otherwise
+// as JSON.
+func (s *Snapshot) Write(w io.Writer, format string) error {
+	if format == "yaml" {
+		return yaml.NewEncoder(w).Encode(s)
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(s)
+}
+
+// SnapshotFormat returns the format of the snapshot file decided by the
+// extension of fpath, "yaml" for .yaml and .yml, otherwise "json".
+func SnapshotFormat(fpath string) string {
+	switch filepath.Ext(fpath) {
+	case ".yaml", ".yml":
+		return "yaml"
+	default:
+		return "json"
+	}
+}
+
+// NewSnapshotSource creates a SchemaSource from a snapshot file written by
+// DumpSnapshot. The file is read as YAML or JSON by its extension.
+func NewSnapshotSource(fpath string) (SchemaSource, error) {
+	b, err := os.ReadFile(fpath)
+	if err != nil {
+		return nil, err
+	}
+
+	var snapshot Snapshot
+	if SnapshotFormat(fpath) == "yaml" {
+		err = yaml.Unmarshal(b, &snapshot)
+	} else {
+		err = json.Unmarshal(b, &snapshot)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read snapshot %s: %v", fpath, err)
+	}
+
+	if snapshot.Version != SnapshotVersion {
+		return nil, fmt.Errorf("unsupported snapshot version %d: must be %d", snapshot.Version, SnapshotVersion)
+	}
+
+	return newSnapshotSource(&snapshot), nil
+}
+
+func newSnapshotSource(snapshot *Snapshot) *snapshotSource {
+	tables := make(map[string]*SnapshotTable)
+	for _, t := range snapshot.Tables {
+		tables[qualifiedName(t.TableSchema, t.TableName)] = t
+	}
+
+	return &snapshotSource{snapshot: snapshot, tables: tables}
+}
+
+type snapshotSource struct {
+	snapshot *Snapshot
+	tables   map[string]*SnapshotTable
+}
+
+func (s *snapshotSource) Dialect() (string, error) {
+	return s.snapshot.Dialect, nil
+}
+
+func (s *snapshotSource) TableList() ([]*SpannerTable, error) {
+	var tables []*SpannerTable
+	for _, t := range s.snapshot.Tables {
+		t := t.SpannerTable
+		tables = append(tables, &t)
+	}
+
+	return tables, nil
+}
+
+func (s *snapshotSource) ColumnList(table string) ([]*SpannerColumn, error) {
+	t, ok := s.tables[table]
+	if !ok {
+		return nil, nil
+	}
+
+	return t.Columns, nil
+}
+
+func (s *snapshotSource) IndexList(table string) ([]*SpannerIndex, error) {
+	t, ok := s.tables[table]
+	if !ok {
+		return nil, nil
+	}
+
+	var indexes []*SpannerIndex
+	for _, ix := range t.Indexes {
+		ix := ix.SpannerIndex
+		indexes = append(indexes, &ix)
+	}
+
+	return indexes, nil
+}
+
+func (s *snapshotSource) IndexColumnList(table, index string) ([]*SpannerIndexColumn, error) {
+	t, ok := s.tables[table]
+	if !ok {
+		return nil, nil
+	}
+
+	if index == "PRIMARY_KEY" {
+		return t.PrimaryKey, nil
+	}
+
+	for _, ix := range t.Indexes {
+		if ix.IndexName == index {
+			return ix.Columns, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (s *snapshotSource) ForeignKeyList(table string) ([]*SpannerForeignKey, error) {
+	t, ok := s.tables[table]
+	if !ok {
+		return nil, nil
+	}
+
+	return t.ForeignKeys, nil
+}
+
+func (s *snapshotSource) CheckConstraintList(table string) ([]*SpannerCheckConstraint, error) {
+	t, ok := s.tables[table]
+	if !ok {
+		return nil, nil
+	}
+
+	return t.CheckConstraints, nil
+}
+
+func (s *snapshotSource) ChangeStreamList() ([]*SpannerChangeStream, error) {
+	return s.snapshot.ChangeStreams, nil
+}
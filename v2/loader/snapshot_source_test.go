@@ -0,0 +1,121 @@
+// Copyright (c) 2020 Mercari, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package loader
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"go.mercari.io/yo/v2/internal"
+)
+
+func TestSnapshotSource(t *testing.T) {
+	schema := `
+CREATE TABLE Singers (
+  SingerId INT64 NOT NULL,
+  Name STRING(MAX) NOT NULL DEFAULT ("unknown"),
+  UpdatedAt TIMESTAMP OPTIONS (allow_commit_timestamp = true),
+  CONSTRAINT CK_Name CHECK (Name != ""),
+) PRIMARY KEY(SingerId);
+
+CREATE TABLE Albums (
+  SingerId INT64 NOT NULL,
+  AlbumId INT64 NOT NULL,
+  Title STRING(MAX),
+  CONSTRAINT FK_Singer FOREIGN KEY (SingerId) REFERENCES Singers (SingerId),
+) PRIMARY KEY(SingerId, AlbumId),
+  INTERLEAVE IN PARENT Singers ON DELETE CASCADE;
+
+CREATE UNIQUE INDEX AlbumsByTitle ON Albums(Title) STORING (AlbumId);
+
+CREATE VIEW SingerNames SQL SECURITY INVOKER AS SELECT SingerId, Name FROM Singers;
+
+CREATE CHANGE STREAM SingerStream FOR Singers(Name) OPTIONS (retention_period = '7d');
+`
+
+	dir := t.TempDir()
+	ddlPath := filepath.Join(dir, "schema.sql")
+	if err := os.WriteFile(ddlPath, []byte(schema), 0o644); err != nil {
+		t.Fatalf("failed to write schema: %v", err)
+	}
+
+	parserSource, err := NewSchemaParserSource(ddlPath)
+	if err != nil {
+		t.Fatalf("failed to create schema parser source: %v", err)
+	}
+
+	inflector, err := internal.NewInflector(nil)
+	if err != nil {
+		t.Fatalf("failed to create inflector: %v", err)
+	}
+
+	expected, err := NewTypeLoader(parserSource, inflector, Option{}).LoadSchema()
+	if err != nil {
+		t.Fatalf("failed to load schema: %v", err)
+	}
+
+	snapshot, err := DumpSnapshot(parserSource)
+	if err != nil {
+		t.Fatalf("failed to dump snapshot: %v", err)
+	}
+
+	for _, name := range []string{"schema.json", "schema.yaml"} {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(dir, name)
+			f, err := os.Create(path)
+			if err != nil {
+				t.Fatalf("failed to create snapshot file: %v", err)
+			}
+			if err := snapshot.Write(f, SnapshotFormat(path)); err != nil {
+				t.Fatalf("failed to write snapshot: %v", err)
+			}
+			_ = f.Close()
+
+			source, err := NewSnapshotSource(path)
+			if err != nil {
+				t.Fatalf("failed to create snapshot source: %v", err)
+			}
+
+			got, err := NewTypeLoader(source, inflector, Option{}).LoadSchema()
+			if err != nil {
+				t.Fatalf("failed to load schema: %v", err)
+			}
+
+			if diff := cmp.Diff(got, expected); diff != "" {
+				t.Errorf("(-got, +want)\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSnapshotSource_UnsupportedVersion(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "schema.json")
+	if err := os.WriteFile(path, []byte(`{"version": 2, "tables": []}`), 0o644); err != nil {
+		t.Fatalf("failed to write snapshot: %v", err)
+	}
+
+	_, err := NewSnapshotSource(path)
+	if err == nil || !strings.Contains(err.Error(), "unsupported snapshot version 2") {
+		t.Errorf("expect unsupported version error but got %v", err)
+	}
+}
@@ -27,6 +27,8 @@ import (
 	"time"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"go.mercari.io/yo/v2/models"
 	"go.mercari.io/yo/v2/test/testutil"
 )
 
@@ -105,6 +107,32 @@ CREATE TABLE Interleaved (
 ) PRIMARY KEY(Id, InterleavedId),
 INTERLEAVE IN PARENT Parent;
 CREATE INDEX InterleavedKey ON Interleaved(Id, Value), INTERLEAVE IN Parent
+`
+
+	testSchema6 = `
+CREATE TABLE Defaults (
+  Id INT64 NOT NULL,
+  Quantity INT64 NOT NULL DEFAULT (10),
+  Name STRING(32) DEFAULT ("unknown"),
+) PRIMARY KEY(Id);
+`
+
+	testSchema7 = `
+CREATE TABLE CommitTimestamps (
+  Id INT64 NOT NULL,
+  CreatedAt TIMESTAMP NOT NULL OPTIONS (allow_commit_timestamp = true),
+  UpdatedAt TIMESTAMP OPTIONS (allow_commit_timestamp = false),
+) PRIMARY KEY(Id);
+`
+
+	testSchema8 = `
+CREATE TABLE Checks (
+  Id INT64 NOT NULL,
+  Price INT64 NOT NULL,
+  CONSTRAINT CK_MaxPrice CHECK (Price < 1000),
+) PRIMARY KEY(Id);
+
+ALTER TABLE Checks ADD CONSTRAINT CK_MinPrice CHECK (Price > 0);
 `
 )
 
@@ -118,6 +146,9 @@ func TestSource(t *testing.T) {
 		expectedColumns      map[string][]*SpannerColumn
 		expectedIndex        map[string][]*SpannerIndex
 		expectedIndexColumns map[string][]*SpannerIndexColumn
+		expectedForeignKeys  map[string][]*SpannerForeignKey
+		expectedStreams      []*SpannerChangeStream
+		expectedChecks       map[string][]*SpannerCheckConstraint
 	}{
 		{
 			name:   "Simple",
@@ -151,11 +182,13 @@ func TestSource(t *testing.T) {
 						IndexName: "SimpleIndex",
 						IsUnique:  false,
 						IsPrimary: false,
+						IndexType: "INDEX",
 					},
 					{
 						IndexName: "SimpleIndex2",
 						IsUnique:  true,
 						IsPrimary: false,
+						IndexType: "INDEX",
 					},
 				},
 			},
@@ -177,6 +210,13 @@ func TestSource(t *testing.T) {
 					},
 				},
 			},
+			expectedForeignKeys: map[string][]*SpannerForeignKey{},
+			expectedStreams: []*SpannerChangeStream{
+				{
+					ChangeStreamName: "EverythingStream",
+					All:              true,
+				},
+			},
 		},
 		{
 			name:   "MaxLength",
@@ -208,6 +248,7 @@ func TestSource(t *testing.T) {
 				"MaxLengths": nil,
 			},
 			expectedIndexColumns: map[string][]*SpannerIndexColumn{},
+			expectedForeignKeys:  map[string][]*SpannerForeignKey{},
 		},
 		{
 			name:   "FullTypes",
@@ -254,6 +295,7 @@ func TestSource(t *testing.T) {
 				"FullTypes": nil,
 			},
 			expectedIndexColumns: map[string][]*SpannerIndexColumn{},
+			expectedForeignKeys:  map[string][]*SpannerForeignKey{},
 		},
 		{
 			name:   "ForeignKey",
@@ -280,6 +322,17 @@ func TestSource(t *testing.T) {
 				"ForeignItems": nil,
 			},
 			expectedIndexColumns: map[string][]*SpannerIndexColumn{},
+			expectedForeignKeys: map[string][]*SpannerForeignKey{
+				"ForeignItems": {
+					{
+						ConstraintName: "FK_ItemID_ForeignItems",
+						ColumnNames:    []string{"ItemID"},
+						RefTableName:   "Items",
+						RefColumnNames: []string{"ID"},
+						OnDeleteAction: "NO ACTION",
+					},
+				},
+			},
 		},
 		{
 			name:   "Interleave",
@@ -288,6 +341,8 @@ func TestSource(t *testing.T) {
 				{
 					TableName:       "Interleaved",
 					ParentTableName: "Parent",
+					OnDeleteAction:  "NO ACTION",
+					InterleaveType:  "IN PARENT",
 				},
 				{
 					TableName: "Parent",
@@ -306,7 +361,7 @@ func TestSource(t *testing.T) {
 			expectedIndex: map[string][]*SpannerIndex{
 				"Parent": nil,
 				"Interleaved": {
-					{IndexName: "InterleavedKey"},
+					{IndexName: "InterleavedKey", ParentTableName: "Parent", IndexType: "INDEX"},
 				},
 			},
 			expectedIndexColumns: map[string][]*SpannerIndexColumn{
@@ -315,6 +370,75 @@ func TestSource(t *testing.T) {
 					{SeqNo: 2, ColumnName: "Value"},
 				},
 			},
+			expectedForeignKeys: map[string][]*SpannerForeignKey{},
+		},
+		{
+			name:   "Default",
+			schema: testSchema6,
+			expectedTables: []*SpannerTable{
+				{
+					TableName: "Defaults",
+				},
+			},
+			expectedColumns: map[string][]*SpannerColumn{
+				"Defaults": {
+					{FieldOrdinal: 1, ColumnName: "Id", DataType: "INT64", NotNull: true, IsPrimaryKey: true},
+					{FieldOrdinal: 2, ColumnName: "Quantity", DataType: "INT64", NotNull: true, HasDefault: true, DefaultExpr: "10"},
+					{FieldOrdinal: 3, ColumnName: "Name", DataType: "STRING(32)", HasDefault: true, DefaultExpr: `"unknown"`},
+				},
+			},
+			expectedIndex: map[string][]*SpannerIndex{
+				"Defaults": nil,
+			},
+			expectedIndexColumns: map[string][]*SpannerIndexColumn{},
+			expectedForeignKeys:  map[string][]*SpannerForeignKey{},
+		},
+		{
+			name:   "CommitTimestamp",
+			schema: testSchema7,
+			expectedTables: []*SpannerTable{
+				{
+					TableName: "CommitTimestamps",
+				},
+			},
+			expectedColumns: map[string][]*SpannerColumn{
+				"CommitTimestamps": {
+					{FieldOrdinal: 1, ColumnName: "Id", DataType: "INT64", NotNull: true, IsPrimaryKey: true},
+					{FieldOrdinal: 2, ColumnName: "CreatedAt", DataType: "TIMESTAMP", NotNull: true, AllowCommitTimestamp: true},
+					{FieldOrdinal: 3, ColumnName: "UpdatedAt", DataType: "TIMESTAMP"},
+				},
+			},
+			expectedIndex: map[string][]*SpannerIndex{
+				"CommitTimestamps": nil,
+			},
+			expectedIndexColumns: map[string][]*SpannerIndexColumn{},
+			expectedForeignKeys:  map[string][]*SpannerForeignKey{},
+		},
+		{
+			name:   "CheckConstraint",
+			schema: testSchema8,
+			expectedTables: []*SpannerTable{
+				{
+					TableName: "Checks",
+				},
+			},
+			expectedColumns: map[string][]*SpannerColumn{
+				"Checks": {
+					{FieldOrdinal: 1, ColumnName: "Id", DataType: "INT64", NotNull: true, IsPrimaryKey: true},
+					{FieldOrdinal: 2, ColumnName: "Price", DataType: "INT64", NotNull: true},
+				},
+			},
+			expectedIndex: map[string][]*SpannerIndex{
+				"Checks": nil,
+			},
+			expectedIndexColumns: map[string][]*SpannerIndexColumn{},
+			expectedForeignKeys:  map[string][]*SpannerForeignKey{},
+			expectedChecks: map[string][]*SpannerCheckConstraint{
+				"Checks": {
+					{ConstraintName: "CK_MaxPrice", CheckClause: "Price < 1000"},
+					{ConstraintName: "CK_MinPrice", CheckClause: "Price > 0"},
+				},
+			},
 		},
 	}
 
@@ -346,7 +470,7 @@ func TestSource(t *testing.T) {
 			}
 			defer client.Close()
 
-			informationSchemaSource, err := NewInformationSchemaSource(client)
+			informationSchemaSource, err := NewInformationSchemaSource(ctx, client)
 			if err != nil {
 				t.Fatalf("failed to create information schema source: %v", err)
 			}
@@ -412,8 +536,138 @@ func TestSource(t *testing.T) {
 					if diff := cmp.Diff(tc.expectedIndexColumns, gotIndexColumns); diff != "" {
 						t.Errorf("(-got, +want)\n%s", diff)
 					}
+
+					gotForeignKeys := make(map[string][]*SpannerForeignKey)
+					for _, tbl := range tables {
+						fks, err := s.ForeignKeyList(tbl)
+						if err != nil {
+							t.Fatalf("ForeignKeyList failed: %v", err)
+						}
+						if len(fks) > 0 {
+							gotForeignKeys[tbl] = fks
+						}
+					}
+
+					if diff := cmp.Diff(tc.expectedForeignKeys, gotForeignKeys); diff != "" {
+						t.Errorf("(-got, +want)\n%s", diff)
+					}
+
+					gotChecks := make(map[string][]*SpannerCheckConstraint)
+					for _, tbl := range tables {
+						checks, err := s.CheckConstraintList(tbl)
+						if err != nil {
+							t.Fatalf("CheckConstraintList failed: %v", err)
+						}
+						if len(checks) > 0 {
+							gotChecks[tbl] = checks
+						}
+					}
+
+					if diff := cmp.Diff(tc.expectedChecks, gotChecks, cmpopts.EquateEmpty()); diff != "" {
+						t.Errorf("(-got, +want)\n%s", diff)
+					}
+
+					streams, err := s.ChangeStreamList()
+					if err != nil {
+						t.Fatalf("ChangeStreamList failed: %v", err)
+					}
+
+					// INFORMATION_SCHEMA may contain default options
+					if diff := cmp.Diff(tc.expectedStreams, streams, cmpopts.IgnoreFields(SpannerChangeStream{}, "Options")); diff != "" {
+						t.Errorf("(-got, +want)\n%s", diff)
+					}
 				})
 			}
 		})
 	}
 }
+
+func TestSource_PostgreSQL(t *testing.T) {
+	schema := `
+CREATE TABLE simple (
+  id bigint NOT NULL,
+  value character varying(32) NOT NULL,
+  note character varying,
+  PRIMARY KEY (id)
+);
+CREATE INDEX simple_index ON simple (value);
+CREATE UNIQUE INDEX simple_index2 ON simple (id, value);
+CREATE INDEX simple_note_index ON simple (note) WHERE note IS NOT NULL;
+CREATE CHANGE STREAM everything_stream FOR ALL;
+CREATE CHANGE STREAM note_stream FOR simple(note);
+CREATE CHANGE STREAM simple_stream FOR simple;
+`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if err := testutil.SetupPostgreSQLDatabase(ctx, "yo-test", "yo-loader-test", "source-pg-test", schema); err != nil {
+		t.Fatalf("failed to setup database: %v", err)
+	}
+
+	client, err := testutil.TestClient(ctx, "yo-test", "yo-loader-test", "source-pg-test")
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	s, err := NewInformationSchemaSource(ctx, client)
+	if err != nil {
+		t.Fatalf("failed to create information schema source: %v", err)
+	}
+
+	dialect, err := s.Dialect()
+	if err != nil {
+		t.Fatalf("Dialect failed: %v", err)
+	}
+	if dialect != models.DialectPostgreSQL {
+		t.Errorf("expect dialect %s, but got %s", models.DialectPostgreSQL, dialect)
+	}
+
+	tables, err := s.TableList()
+	if err != nil {
+		t.Fatalf("TableList failed: %v", err)
+	}
+	if diff := cmp.Diff(tables, []*SpannerTable{{TableName: "simple"}}); diff != "" {
+		t.Errorf("(-got, +want)\n%s", diff)
+	}
+
+	columns, err := s.ColumnList("simple")
+	if err != nil {
+		t.Fatalf("ColumnList failed: %v", err)
+	}
+	expectedColumns := []*SpannerColumn{
+		{FieldOrdinal: 1, ColumnName: "id", DataType: "bigint", NotNull: true, IsPrimaryKey: true},
+		{FieldOrdinal: 2, ColumnName: "value", DataType: "character varying(32)", NotNull: true},
+		{FieldOrdinal: 3, ColumnName: "note", DataType: "character varying"},
+	}
+	if diff := cmp.Diff(columns, expectedColumns); diff != "" {
+		t.Errorf("(-got, +want)\n%s", diff)
+	}
+
+	indexes, err := s.IndexList("simple")
+	if err != nil {
+		t.Fatalf("IndexList failed: %v", err)
+	}
+	expectedIndexes := []*SpannerIndex{
+		{IndexName: "simple_index", IndexType: "INDEX"},
+		{IndexName: "simple_index2", IsUnique: true, IndexType: "INDEX"},
+		{IndexName: "simple_note_index", IsNullFiltered: true, IndexType: "INDEX"},
+	}
+	if diff := cmp.Diff(indexes, expectedIndexes); diff != "" {
+		t.Errorf("(-got, +want)\n%s", diff)
+	}
+
+	streams, err := s.ChangeStreamList()
+	if err != nil {
+		t.Fatalf("ChangeStreamList failed: %v", err)
+	}
+	expectedStreams := []*SpannerChangeStream{
+		{ChangeStreamName: "everything_stream", All: true},
+		{ChangeStreamName: "note_stream", Tables: []*SpannerChangeStreamTable{{TableName: "simple", ColumnNames: []string{"note"}}}},
+		{ChangeStreamName: "simple_stream", Tables: []*SpannerChangeStreamTable{{TableName: "simple", AllColumns: true}}},
+	}
+	if diff := cmp.Diff(streams, expectedStreams, cmpopts.IgnoreFields(SpannerChangeStream{}, "Options")); diff != "" {
+		t.Errorf("(-got, +want)\n%s", diff)
+	}
+}
@@ -0,0 +1,179 @@
+// Copyright (c) 2020 Mercari, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package loader
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/kenshaw/snaker"
+
+	"go.mercari.io/yo/v2/config"
+	"go.mercari.io/yo/v2/models"
+)
+
+// Naming strategies of the json tags.
+const (
+	jsonNamingColumn     = "column"
+	jsonNamingSnake      = "snake_case"
+	jsonNamingLowerCamel = "lowerCamelCase"
+	jsonNamingField      = "field"
+)
+
+// tagFuncs are the funcs available in the templates of the tag values.
+var tagFuncs = template.FuncMap{
+	"snake":      snaker.CamelToSnakeIdentifier,
+	"lowerCamel": snaker.ForceLowerCamelIdentifier,
+}
+
+// tagBuilder builds the struct tags of fields from the config.
+type tagBuilder struct {
+	jsonNaming    string
+	jsonOmitEmpty bool
+	extra         []*tagTemplate
+}
+
+// tagTemplate is a key of a tag and the template of its value.
+type tagTemplate struct {
+	key  string
+	tmpl *template.Template
+}
+
+func newTagBuilder(cfg config.Tags) (*tagBuilder, error) {
+	b := &tagBuilder{
+		jsonNaming:    cfg.JSONNaming,
+		jsonOmitEmpty: cfg.JSONOmitEmpty,
+	}
+
+	switch b.jsonNaming {
+	case "":
+		b.jsonNaming = jsonNamingColumn
+	case jsonNamingColumn, jsonNamingSnake, jsonNamingLowerCamel, jsonNamingField:
+	default:
+		return nil, fmt.Errorf("unknown jsonNaming %s in the config: it must be %s, %s, %s or %s",
+			b.jsonNaming, jsonNamingColumn, jsonNamingSnake, jsonNamingLowerCamel, jsonNamingField)
+	}
+
+	keys := map[string]bool{"spanner": true, "json": true}
+	for _, t := range cfg.Extra {
+		if err := validateTagKey(t.Key); err != nil {
+			return nil, err
+		}
+		if keys[t.Key] {
+			return nil, fmt.Errorf("duplicate tag %s in the config", t.Key)
+		}
+		keys[t.Key] = true
+
+		tmpl, err := template.New(t.Key).Funcs(tagFuncs).Option("missingkey=error").Parse(t.Value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value of tag %s in the config: %v", t.Key, err)
+		}
+		b.extra = append(b.extra, &tagTemplate{key: t.Key, tmpl: tmpl})
+	}
+
+	return b, nil
+}
+
+// build returns the tags of the field. overrides replaces the values of the
+// tags by the keys, and an empty value omits the tag.
+func (b *tagBuilder) build(f *models.Field, overrides map[string]string) ([]*models.Tag, error) {
+	tags := []*models.Tag{
+		{Key: "spanner", Value: f.ColumnName},
+		{Key: "json", Value: b.jsonName(f)},
+	}
+	for _, t := range b.extra {
+		var sb strings.Builder
+		if err := t.tmpl.Execute(&sb, f); err != nil {
+			return nil, fmt.Errorf("failed to execute the value of tag %s: %v", t.key, err)
+		}
+		tags = append(tags, &models.Tag{Key: t.key, Value: sb.String()})
+	}
+
+	// apply the overrides to the tags, and append the others sorted by the keys
+	var others []string
+	for k := range overrides {
+		if k == "spanner" {
+			return nil, fmt.Errorf("tag spanner cannot be overridden")
+		}
+		if err := validateTagKey(k); err != nil {
+			return nil, err
+		}
+
+		found := false
+		for _, t := range tags {
+			if t.Key == k {
+				t.Value = overrides[k]
+				found = true
+			}
+		}
+		if !found {
+			others = append(others, k)
+		}
+	}
+	sort.Strings(others)
+	for _, k := range others {
+		tags = append(tags, &models.Tag{Key: k, Value: overrides[k]})
+	}
+
+	res := make([]*models.Tag, 0, len(tags))
+	for _, t := range tags {
+		if t.Value == "" {
+			continue
+		}
+		if strings.ContainsAny(t.Value, "`\"") {
+			return nil, fmt.Errorf("value of tag %s must not contain quotes: %s", t.Key, t.Value)
+		}
+		res = append(res, t)
+	}
+
+	return res, nil
+}
+
+func (b *tagBuilder) jsonName(f *models.Field) string {
+	var name string
+	switch b.jsonNaming {
+	case jsonNamingSnake:
+		name = snaker.CamelToSnakeIdentifier(f.ColumnName)
+	case jsonNamingLowerCamel:
+		name = snaker.ForceLowerCamelIdentifier(f.ColumnName)
+	case jsonNamingField:
+		name = f.Name
+	default:
+		name = f.ColumnName
+	}
+
+	if b.jsonOmitEmpty {
+		name += ",omitempty"
+	}
+	return name
+}
+
+// validateTagKey returns an error if the key cannot be a key of a struct tag.
+func validateTagKey(key string) error {
+	if key == "" {
+		return fmt.Errorf("tag key must not be empty")
+	}
+	if strings.ContainsAny(key, " :\"`") {
+		return fmt.Errorf("invalid tag key %q", key)
+	}
+	return nil
+}
@@ -21,31 +21,75 @@ package loader
 
 // SpannerTable represents table info.
 type SpannerTable struct {
-	TableName       string // table_name
-	ParentTableName string
+	TableSchema       string `json:"tableSchema,omitempty" yaml:"tableSchema,omitempty"`             // table_schema. Empty for the default schema.
+	TableName         string `json:"tableName" yaml:"tableName"`                                     // table_name
+	ParentTableName   string `json:"parentTableName,omitempty" yaml:"parentTableName,omitempty"`     // parent_table_name
+	OnDeleteAction    string `json:"onDeleteAction,omitempty" yaml:"onDeleteAction,omitempty"`       // on_delete_action. CASCADE or NO ACTION for INTERLEAVE IN PARENT
+	InterleaveType    string `json:"interleaveType,omitempty" yaml:"interleaveType,omitempty"`       // interleave_type. IN or IN PARENT for an interleaved table
+	IsView            bool   `json:"isView,omitempty" yaml:"isView,omitempty"`                       // table_type is VIEW or not
+	RowDeletionPolicy string `json:"rowDeletionPolicy,omitempty" yaml:"rowDeletionPolicy,omitempty"` // row_deletion_policy_expression
 }
 
 // SpannerColumn represents column info.
 type SpannerColumn struct {
-	FieldOrdinal int    // field_ordinal
-	ColumnName   string // column_name
-	DataType     string // data_type
-	NotNull      bool   // not_null
-	IsPrimaryKey bool   // is_primary_key
-	IsGenerated  bool   // is_generated
-	IsHidden     bool   // is_hidden
+	FieldOrdinal         int    `json:"fieldOrdinal" yaml:"fieldOrdinal"`                                     // field_ordinal
+	ColumnName           string `json:"columnName" yaml:"columnName"`                                         // column_name
+	DataType             string `json:"dataType" yaml:"dataType"`                                             // data_type
+	NotNull              bool   `json:"notNull,omitempty" yaml:"notNull,omitempty"`                           // not_null
+	IsPrimaryKey         bool   `json:"isPrimaryKey,omitempty" yaml:"isPrimaryKey,omitempty"`                 // is_primary_key
+	IsGenerated          bool   `json:"isGenerated,omitempty" yaml:"isGenerated,omitempty"`                   // is_generated
+	IsHidden             bool   `json:"isHidden,omitempty" yaml:"isHidden,omitempty"`                         // is_hidden
+	HasDefault           bool   `json:"hasDefault,omitempty" yaml:"hasDefault,omitempty"`                     // column_default is not null
+	DefaultExpr          string `json:"defaultExpr,omitempty" yaml:"defaultExpr,omitempty"`                   // column_default
+	AllowCommitTimestamp bool   `json:"allowCommitTimestamp,omitempty" yaml:"allowCommitTimestamp,omitempty"` // allow_commit_timestamp option is true
 }
 
 // SpannerIndex represents an index.
 type SpannerIndex struct {
-	IndexName string // index name
-	IsUnique  bool   // the index is unique ro not
-	IsPrimary bool   // the index is primary key or not
+	IndexName       string `json:"indexName" yaml:"indexName"`                                 // index name
+	IsUnique        bool   `json:"isUnique,omitempty" yaml:"isUnique,omitempty"`               // the index is unique ro not
+	IsPrimary       bool   `json:"isPrimary,omitempty" yaml:"isPrimary,omitempty"`             // the index is primary key or not
+	IsNullFiltered  bool   `json:"isNullFiltered,omitempty" yaml:"isNullFiltered,omitempty"`   // is_null_filtered
+	ParentTableName string `json:"parentTableName,omitempty" yaml:"parentTableName,omitempty"` // parent_table_name. The table of INTERLEAVE IN
+	IndexType       string `json:"indexType,omitempty" yaml:"indexType,omitempty"`             // index_type. INDEX, SEARCH or VECTOR
 }
 
 // SpannerIndexColumn represents index column info.
 type SpannerIndexColumn struct {
-	SeqNo      int    // seq_no. If is'a Storing Column, this value is 0.
-	ColumnName string // column_name
-	Storing    bool   // storing column or not
+	SeqNo      int    `json:"seqNo" yaml:"seqNo"`                         // seq_no. If is'a Storing Column, this value is 0.
+	ColumnName string `json:"columnName" yaml:"columnName"`               // column_name
+	Storing    bool   `json:"storing,omitempty" yaml:"storing,omitempty"` // storing column or not
+	Desc       bool   `json:"desc,omitempty" yaml:"desc,omitempty"`       // column_ordering is DESC or not
+}
+
+// SpannerForeignKey represents a foreign key.
+type SpannerForeignKey struct {
+	ConstraintName string   `json:"constraintName,omitempty" yaml:"constraintName,omitempty"` // constraint_name
+	ColumnNames    []string `json:"columnNames,omitempty" yaml:"columnNames,omitempty"`       // column_name of the referencing columns in order
+	RefTableSchema string   `json:"refTableSchema,omitempty" yaml:"refTableSchema,omitempty"` // table_schema of the referenced table
+	RefTableName   string   `json:"refTableName" yaml:"refTableName"`                         // table_name of the referenced table
+	RefColumnNames []string `json:"refColumnNames" yaml:"refColumnNames"`                     // column_name of the referenced columns in order
+	OnDeleteAction string   `json:"onDeleteAction,omitempty" yaml:"onDeleteAction,omitempty"` // delete_rule. CASCADE or NO ACTION
+}
+
+// SpannerCheckConstraint represents a check constraint.
+type SpannerCheckConstraint struct {
+	ConstraintName string `json:"constraintName,omitempty" yaml:"constraintName,omitempty"` // constraint_name
+	CheckClause    string `json:"checkClause" yaml:"checkClause"`                           // check_clause
+}
+
+// SpannerChangeStream represents a change stream.
+type SpannerChangeStream struct {
+	ChangeStreamName string                      `json:"changeStreamName" yaml:"changeStreamName"`   // change_stream_name
+	All              bool                        `json:"all,omitempty" yaml:"all,omitempty"`         // all
+	Tables           []*SpannerChangeStreamTable `json:"tables,omitempty" yaml:"tables,omitempty"`   // watched tables. Empty if All is true
+	Options          map[string]string           `json:"options,omitempty" yaml:"options,omitempty"` // option_name to option_value
+}
+
+// SpannerChangeStreamTable represents a table watched by a change stream.
+type SpannerChangeStreamTable struct {
+	TableSchema string   `json:"tableSchema,omitempty" yaml:"tableSchema,omitempty"` // table_schema. Empty for the default schema.
+	TableName   string   `json:"tableName" yaml:"tableName"`                         // table_name
+	AllColumns  bool     `json:"allColumns,omitempty" yaml:"allColumns,omitempty"`   // all_columns
+	ColumnNames []string `json:"columnNames,omitempty" yaml:"columnNames,omitempty"` // column_name of the watched columns if AllColumns is false
 }
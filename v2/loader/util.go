@@ -20,18 +20,24 @@
 package loader
 
 import (
+	"fmt"
+	"path"
 	"regexp"
 	"strconv"
 	"strings"
 
-	"go.mercari.io/yo/v2/internal"
+	"go.mercari.io/yo/v2/models"
 )
 
 var lengthRegexp = regexp.MustCompile(`\(([0-9]+|MAX)\)$`)
 
 // SpanParseType parse a Spanner type into a Go type based on the column
-// definition.
-func parseSpannerType(dt string, nullable bool) (int, string, string) {
+// definition. It returns an error for a type that yo does not support.
+func parseSpannerType(dt string, nullable bool, dialect string) (int, string, string, error) {
+	if dialect == models.DialectPostgreSQL {
+		dt = pgSpannerType(dt)
+	}
+
 	nilVal := "nil"
 	length := -1
 
@@ -79,6 +85,14 @@ func parseSpannerType(dt string, nullable bool) (int, string, string) {
 			typ = "spanner.NullInt64"
 		}
 
+	case "FLOAT32":
+		nilVal = "0.0"
+		typ = "float32"
+		if nullable {
+			nilVal = "spanner.NullFloat32{}"
+			typ = "spanner.NullFloat32"
+		}
+
 	case "FLOAT64":
 		nilVal = "0.0"
 		typ = "float64"
@@ -114,6 +128,27 @@ func parseSpannerType(dt string, nullable bool) (int, string, string) {
 			typ = "spanner.NullNumeric"
 		}
 
+	case "INTERVAL":
+		nilVal = "spanner.Interval{}"
+		typ = "spanner.Interval"
+		if nullable {
+			nilVal = "spanner.NullInterval{}"
+			typ = "spanner.NullInterval"
+		}
+
+	case "UUID":
+		nilVal = "uuid.UUID{}"
+		typ = "uuid.UUID"
+		if nullable {
+			nilVal = "spanner.NullUUID{}"
+			typ = "spanner.NullUUID"
+		}
+
+	case "TOKENLIST":
+		// TOKENLIST is only used by search indexes and cannot be read. The
+		// column is treated as HIDDEN and not in the generated struct.
+		typ = "[]byte"
+
 	case "JSON":
 		nilVal = `spanner.NullJSON{Valid: true}`
 		typ = "spanner.NullJSON"
@@ -121,10 +156,26 @@ func parseSpannerType(dt string, nullable bool) (int, string, string) {
 			nilVal = `spanner.NullJSON{}`
 		}
 
+	case "PG_NUMERIC":
+		nilVal = `spanner.PGNumeric{Numeric: "0", Valid: true}`
+		typ = "spanner.PGNumeric"
+		if nullable {
+			nilVal = `spanner.PGNumeric{}`
+		}
+
+	case "PG_JSONB":
+		nilVal = `spanner.PGJsonB{Valid: true}`
+		typ = "spanner.PGJsonB"
+		if nullable {
+			nilVal = `spanner.PGJsonB{}`
+		}
+
 	default:
-		if strings.HasPrefix(dt, "ARRAY<") {
-			eleDataType := strings.TrimSuffix(strings.TrimPrefix(dt, "ARRAY<"), ">")
-			_, _, eleTyp := parseSpannerType(eleDataType, false)
+		if eleDataType, ok := arrayElementType(dt); ok {
+			_, _, eleTyp, err := parseSpannerType(eleDataType, false, models.DialectGoogleSQL)
+			if err != nil {
+				return 0, "", "", err
+			}
 			typ, nilVal = "[]"+eleTyp, "nil"
 			if !nullable {
 				nilVal = typ + "{}"
@@ -132,9 +183,199 @@ func parseSpannerType(dt string, nullable bool) (int, string, string) {
 			break
 		}
 
-		typ = internal.SnakeToCamel(dt)
-		nilVal = typ + "{}"
+		return 0, "", "", fmt.Errorf("unsupported type %s: add it to types in the config", dt)
+	}
+
+	return length, nilVal, typ, nil
+}
+
+var arrayTypeRegexp = regexp.MustCompile(`^ARRAY<(.+)>(\(.*\))?$`)
+
+// arrayElementType returns the element type of an ARRAY type such as
+// ARRAY<STRING(MAX)>. The options of the type such as vector_length are
+// ignored. It returns false if dt is not an ARRAY type.
+func arrayElementType(dt string) (string, bool) {
+	m := arrayTypeRegexp.FindStringSubmatch(dt)
+	if m == nil {
+		return "", false
+	}
+	return m[1], true
+}
+
+// typeImportPaths maps the package names used by the Go types of Spanner
+// types to their import paths, which goimports cannot always resolve.
+var typeImportPaths = map[string]string{
+	"uuid": "github.com/google/uuid",
+}
+
+// typePackage returns the package name of a Go type such as []*foo.Bar. It
+// returns an empty string for a builtin type.
+func typePackage(typ string) string {
+	typ = strings.TrimLeft(typ, "[]*")
+	if i := strings.LastIndex(typ, "."); i > 0 {
+		return typ[:i]
+	}
+	return ""
+}
+
+// qualifiedName returns the fully qualified name of an object in the named
+// schema. An object in the default schema is returned as is.
+func qualifiedName(schema, name string) string {
+	if schema == "" {
+		return name
+	}
+	return schema + "." + name
+}
+
+// splitQualifiedName splits a fully qualified name into the schema name and
+// the object name. The schema name is empty for the default schema.
+func splitQualifiedName(s string) (string, string) {
+	if i := strings.LastIndex(s, "."); i != -1 {
+		return s[:i], s[i+1:]
+	}
+	return "", s
+}
+
+// parseProtoType returns the NULL value and the Go type of a PROTO or ENUM
+// type. A message is a pointer whose nil is NULL. An enum is a pointer only
+// when it is nullable.
+func parseProtoType(pt *protoColumnType, nullable bool) (string, string) {
+	switch {
+	case pt.IsArray && pt.Kind == "PROTO":
+		typ := "[]*" + pt.GoType
+		if nullable {
+			return "nil", typ
+		}
+		return typ + "{}", typ
+
+	case pt.IsArray:
+		typ := "[]" + pt.GoType
+		if nullable {
+			return "nil", typ
+		}
+		return typ + "{}", typ
+
+	case pt.Kind == "PROTO" || nullable:
+		return "nil", "*" + pt.GoType
+
+	default:
+		return pt.GoType + "(0)", pt.GoType
+	}
+}
+
+// pgTypes maps the types of PostgreSQL to the types of GoogleSQL.
+var pgTypes = map[string]string{
+	"boolean":                  "BOOL",
+	"bool":                     "BOOL",
+	"bigint":                   "INT64",
+	"int8":                     "INT64",
+	"double precision":         "FLOAT64",
+	"float8":                   "FLOAT64",
+	"character varying":        "STRING",
+	"varchar":                  "STRING",
+	"text":                     "STRING",
+	"bytea":                    "BYTES",
+	"timestamp with time zone": "TIMESTAMP",
+	"timestamptz":              "TIMESTAMP",
+	"spanner.commit_timestamp": "TIMESTAMP",
+	"spanner.tokenlist":        "TOKENLIST",
+	"date":                     "DATE",
+	"real":                     "FLOAT32",
+	"float4":                   "FLOAT32",
+	"interval":                 "INTERVAL",
+	"uuid":                     "UUID",
+	"numeric":                  "PG_NUMERIC",
+	"jsonb":                    "PG_JSONB",
+}
+
+// pgSpannerType converts a type of PostgreSQL such as character varying(256)
+// or bigint[] into the type of GoogleSQL such as STRING(256) or ARRAY<INT64>.
+// numeric and jsonb are converted into PG_NUMERIC and PG_JSONB after the type
+// annotations of Spanner since they are decoded into the specific types.
+func pgSpannerType(dt string) string {
+	if strings.HasSuffix(dt, "[]") {
+		return "ARRAY<" + pgSpannerType(strings.TrimSuffix(dt, "[]")) + ">"
+	}
+
+	length := ""
+	if m := lengthRegexp.FindStringIndex(dt); m != nil {
+		length = dt[m[0]:m[1]]
+		dt = dt[:m[0]]
+	}
+
+	typ, ok := pgTypes[strings.ToLower(strings.TrimSpace(dt))]
+	if !ok {
+		return dt + length
+	}
+
+	return typ + length
+}
+
+var (
+	// OLDER_THAN(ExpiredAt, INTERVAL 30 DAY)
+	olderThanPolicyRegexp = regexp.MustCompile("(?is)^\\s*OLDER_THAN\\s*\\(\\s*`?(\\w+)`?\\s*,\\s*INTERVAL\\s+(\\d+)\\s+DAYS?\\s*\\)\\s*$")
+	// INTERVAL '30 DAYS' ON expired_at
+	intervalPolicyRegexp = regexp.MustCompile(`(?is)^\s*INTERVAL\s+'\s*(\d+)\s+DAYS?\s*'\s+ON\s+"?(\w+)"?\s*$`)
+)
+
+// parseRowDeletionPolicy parses the expression of a row deletion policy in
+// GoogleSQL or PostgreSQL, and returns the column and the number of days.
+func parseRowDeletionPolicy(expr string) (string, int64, error) {
+	var column, days string
+	if m := olderThanPolicyRegexp.FindStringSubmatch(expr); m != nil {
+		column, days = m[1], m[2]
+	} else if m := intervalPolicyRegexp.FindStringSubmatch(expr); m != nil {
+		column, days = m[2], m[1]
+	} else {
+		return "", 0, fmt.Errorf("unsupported row deletion policy: %s", expr)
+	}
+
+	numDays, err := strconv.ParseInt(days, 10, 64)
+	if err != nil {
+		return "", 0, fmt.Errorf("invalid row deletion policy: %s: %v", expr, err)
+	}
+
+	return column, numDays, nil
+}
+
+// tablePattern is a compiled pattern of table names in the config.
+type tablePattern func(table string) bool
+
+// compileTablePatterns compiles the patterns of table names. A pattern
+// enclosed in slashes such as /^Tmp[0-9]+$/ is a regular expression, and
+// others are glob patterns such as Tmp*.
+func compileTablePatterns(patterns []string) ([]tablePattern, error) {
+	res := make([]tablePattern, 0, len(patterns))
+	for _, p := range patterns {
+		if len(p) >= 2 && strings.HasPrefix(p, "/") && strings.HasSuffix(p, "/") {
+			re, err := regexp.Compile(p[1 : len(p)-1])
+			if err != nil {
+				return nil, fmt.Errorf("invalid table pattern %s: %v", p, err)
+			}
+			res = append(res, re.MatchString)
+			continue
+		}
+
+		if _, err := path.Match(p, ""); err != nil {
+			return nil, fmt.Errorf("invalid table pattern %s: %v", p, err)
+		}
+		pattern := p
+		res = append(res, func(table string) bool {
+			matched, _ := path.Match(pattern, table)
+			return matched
+		})
+	}
+
+	return res, nil
+}
+
+// matchAnyPattern reports whether the table matches any of the patterns.
+func matchAnyPattern(patterns []tablePattern, table string) bool {
+	for _, match := range patterns {
+		if match(table) {
+			return true
+		}
 	}
 
-	return length, nilVal, typ
+	return false
 }
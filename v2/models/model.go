@@ -19,34 +19,80 @@
 
 package models
 
+// Dialects of Spanner databases.
+const (
+	DialectGoogleSQL  = "GOOGLE_STANDARD_SQL"
+	DialectPostgreSQL = "POSTGRESQL"
+)
+
+// Types of indexes.
+const (
+	IndexTypeIndex  = "INDEX"
+	IndexTypeSearch = "SEARCH"
+	IndexTypeVector = "VECTOR"
+)
+
 // Schema contains information of all Go types.
 type Schema struct {
-	Types []*Type
+	Types         []*Type
+	ChangeStreams []*ChangeStream
+	Dialect       string // DialectGoogleSQL or DialectPostgreSQL
 }
 
 // Type is a Go type that represents a Spanner table.
 type Type struct {
-	Name             string // Go like (CamelCase) table name
-	PrimaryKeyFields []*Field
-	Fields           []*Field
-	Indexes          []*Index
-	TableName        string
-	Parent           *Type
+	Name              string // Go like (CamelCase) table name
+	PrimaryKeyFields  []*Field
+	PrimaryKeys       []*IndexKey // primary key fields in order with the sort direction
+	Fields            []*Field
+	Indexes           []*Index
+	TableName         string  // table name. Qualified by the schema name for a named schema
+	Schema            string  // schema name. Empty for the default schema
+	Parent            *Type   // parent table of INTERLEAVE IN (PARENT)
+	Children          []*Type // interleaved tables of this table
+	InterleaveType    string  // IN or IN PARENT for an interleaved table
+	OnDeleteAction    string  // CASCADE or NO ACTION for INTERLEAVE IN PARENT
+	ForeignKeys       []*ForeignKey
+	IsView            bool               // the type is a read-only view or not
+	ChangeStreams     []*ChangeStream    // change streams watching the table
+	CheckConstraints  []*CheckConstraint // CHECK constraints of the table
+	RowDeletionPolicy *RowDeletionPolicy // row deletion policy (TTL) of the table. nil if the table has no policy
+}
+
+// RowDeletionPolicy is a row deletion policy of a table. A row is deleted
+// when the value of the TIMESTAMP column is older than NumDays days.
+type RowDeletionPolicy struct {
+	Field   *Field // TIMESTAMP column of the policy
+	NumDays int64  // number of days of the interval
 }
 
 // Field is a field of Go type that represents a Spanner column.
 type Field struct {
-	Name            string // Go like (CamelCase) field name
-	Type            string // Go type specified by custom type or same to OriginalType below
-	OriginalType    string // Go type corresponding to Spanner type
-	NullValue       string // NULL value for Type
-	Len             int    // Length for STRING, BYTES. -1 for MAX or other types
-	ColumnName      string // column_name
-	SpannerDataType string // data_type
-	IsNotNull       bool   // not_null
-	IsPrimaryKey    bool   // is_primary_key
-	IsGenerated     bool   // is_generated
-	IsHidden        bool   // is_hidden
+	Name                 string // Go like (CamelCase) field name
+	Type                 string // Go type specified by custom type or same to OriginalType below
+	OriginalType         string // Go type corresponding to Spanner type
+	NullValue            string // NULL value for Type
+	Len                  int    // Length for STRING, BYTES. -1 for MAX or other types
+	ColumnName           string // column_name
+	SpannerDataType      string // data_type
+	IsNotNull            bool   // not_null
+	IsPrimaryKey         bool   // is_primary_key
+	IsGenerated          bool   // is_generated
+	IsHidden             bool   // is_hidden
+	HasDefault           bool   // the column has a DEFAULT expression or not
+	DefaultExpr          string // DEFAULT expression of the column
+	AllowCommitTimestamp bool   // allow_commit_timestamp option of the column is true
+	UseCommitTimestamp   bool   // mutations write spanner.CommitTimestamp into the column
+	ProtoKind            string // PROTO or ENUM for a proto column, otherwise empty
+	ProtoName            string // fully qualified name of the proto message or enum
+	ImportPath           string // import path of the Go package of OriginalType such as the proto type. Empty if goimports resolves it
+	Tags                 []*Tag // struct tags of the field in order. The spanner tag comes first
+}
+
+// Tag is a struct tag of a field.
+type Tag struct {
+	Key   string // key of the tag such as json
+	Value string // value of the tag
 }
 
 // Index is a template item for a index into a table.
@@ -58,7 +104,50 @@ type Index struct {
 	Fields         []*Field
 	StoringFields  []*Field
 	NullableFields []*Field
-	IndexName      string // index name
-	IsUnique       bool   // the index is unique ro not
-	IsPrimary      bool   // the index is primary key or not
+	Keys           []*IndexKey // key fields in order with the sort direction
+	IndexName      string      // index name. Qualified by the schema name for a named schema
+	IsUnique       bool        // the index is unique ro not
+	IsPrimary      bool        // the index is primary key or not
+	NullFiltered   bool        // the index is NULL_FILTERED or not
+	InterleavedIn  string      // table name of INTERLEAVE IN. Empty if the index is not interleaved
+	IndexType      string      // IndexTypeIndex, IndexTypeSearch or IndexTypeVector
+}
+
+// IndexKey is a key field of an index.
+type IndexKey struct {
+	Field *Field
+	Desc  bool // the key is sorted in descending order or not
+}
+
+// ForeignKey is a template item for a foreign key of a table.
+type ForeignKey struct {
+	Name           string   // Go like (CamelCase) name of the referenced type. Suffixed by `By` + Field names if the type is referenced by several foreign keys
+	ConstraintName string   // constraint name
+	Type           *Type    // referencing type
+	Fields         []*Field // referencing fields
+	RefType        *Type    // referenced type
+	RefFields      []*Field // referenced fields
+	OnDeleteAction string   // CASCADE or NO ACTION
+}
+
+// CheckConstraint is a template item for a CHECK constraint of a table.
+type CheckConstraint struct {
+	Name string // constraint name. Empty for an unnamed constraint loaded from DDL
+	Expr string // SQL expression of CHECK
+}
+
+// ChangeStream is a template item for a change stream.
+type ChangeStream struct {
+	Name       string // Go like (CamelCase) change stream name
+	StreamName string // change stream name
+	All        bool   // the change stream watches all tables or not
+	Tables     []*ChangeStreamTable
+	Options    map[string]string // option name to value such as retention_period and value_capture_type
+}
+
+// ChangeStreamTable is a table watched by a change stream.
+type ChangeStreamTable struct {
+	Type       *Type
+	AllColumns bool     // all columns are watched or not
+	Fields     []*Field // watched columns other than primary keys if AllColumns is false
 }
@@ -24,12 +24,15 @@ import (
 )
 
 var (
-	Header      = newBuiltin(module.HeaderModule, "header")
-	Type        = newBuiltin(module.TypeModule, "type")
-	Operation   = newBuiltin(module.TypeModule, "operation")
-	Index       = newBuiltin(module.TypeModule, "index")
-	LegacyIndex = newBuiltin(module.TypeModule, "legacy_index")
-	Interface   = newBuiltin(module.GlobalModule, "yo_db")
+	Header       = newBuiltin(module.HeaderModule, "header")
+	Type         = newBuiltin(module.TypeModule, "type")
+	Operation    = newBuiltin(module.TypeModule, "operation")
+	Index        = newBuiltin(module.TypeModule, "index")
+	LegacyIndex  = newBuiltin(module.TypeModule, "legacy_index")
+	ForeignKey   = newBuiltin(module.TypeModule, "foreign_key")
+	Validate     = newBuiltin(module.TypeModule, "validate")
+	Interface    = newBuiltin(module.GlobalModule, "yo_db")
+	ChangeStream = newBuiltin(module.GlobalModule, "change_stream")
 )
 
 var All = []module.Module{
@@ -38,7 +41,10 @@ var All = []module.Module{
 	Operation,
 	Index,
 	LegacyIndex,
+	ForeignKey,
+	Validate,
 	Interface,
+	ChangeStream,
 }
 
 var (
@@ -0,0 +1,249 @@
+{{- if .Schema.ChangeStreams }}
+// ChangeRecord is a row of a change stream query. Each of the records is
+// returned in its own ChangeRecord.
+type ChangeRecord struct {
+	DataChangeRecord      []*DataChangeRecord      `spanner:"data_change_record" json:"data_change_record"`
+	HeartbeatRecord       []*HeartbeatRecord       `spanner:"heartbeat_record" json:"heartbeat_record"`
+	ChildPartitionsRecord []*ChildPartitionsRecord `spanner:"child_partitions_record" json:"child_partitions_record"`
+}
+
+// DataChangeRecord contains a set of changes to a table with the same
+// modification type committed in the same transaction.
+type DataChangeRecord struct {
+	CommitTimestamp                      time.Time                     `spanner:"commit_timestamp" json:"commit_timestamp"`
+	RecordSequence                       string                        `spanner:"record_sequence" json:"record_sequence"`
+	ServerTransactionID                  string                        `spanner:"server_transaction_id" json:"server_transaction_id"`
+	IsLastRecordInTransactionInPartition bool                          `spanner:"is_last_record_in_transaction_in_partition" json:"is_last_record_in_transaction_in_partition"`
+	TableName                            string                        `spanner:"table_name" json:"table_name"`
+	ColumnTypes                          []*DataChangeRecordColumnType `spanner:"column_types" json:"column_types"`
+	Mods                                 []*DataChangeRecordMod        `spanner:"mods" json:"mods"`
+	ModType                              string                        `spanner:"mod_type" json:"mod_type"`
+	ValueCaptureType                     string                        `spanner:"value_capture_type" json:"value_capture_type"`
+	NumberOfRecordsInTransaction         int64                         `spanner:"number_of_records_in_transaction" json:"number_of_records_in_transaction"`
+	NumberOfPartitionsInTransaction      int64                         `spanner:"number_of_partitions_in_transaction" json:"number_of_partitions_in_transaction"`
+	TransactionTag                       string                        `spanner:"transaction_tag" json:"transaction_tag"`
+	IsSystemTransaction                  bool                          `spanner:"is_system_transaction" json:"is_system_transaction"`
+}
+
+// DataChangeRecordColumnType is a type of a column in a DataChangeRecord.
+type DataChangeRecordColumnType struct {
+	Name            string           `spanner:"name" json:"name"`
+	Type            spanner.NullJSON `spanner:"type" json:"type"`
+	IsPrimaryKey    bool             `spanner:"is_primary_key" json:"is_primary_key"`
+	OrdinalPosition int64            `spanner:"ordinal_position" json:"ordinal_position"`
+}
+
+// DataChangeRecordMod is a change to a row in a DataChangeRecord.
+type DataChangeRecordMod struct {
+	Keys      spanner.NullJSON `spanner:"keys" json:"keys"`
+	NewValues spanner.NullJSON `spanner:"new_values" json:"new_values"`
+	OldValues spanner.NullJSON `spanner:"old_values" json:"old_values"`
+}
+
+// HeartbeatRecord indicates that all changes with commit timestamp less than
+// the timestamp have been returned.
+type HeartbeatRecord struct {
+	Timestamp time.Time `spanner:"timestamp" json:"timestamp"`
+}
+
+// ChildPartitionsRecord contains the child partitions to query next.
+type ChildPartitionsRecord struct {
+	StartTimestamp  time.Time         `spanner:"start_timestamp" json:"start_timestamp"`
+	RecordSequence  string            `spanner:"record_sequence" json:"record_sequence"`
+	ChildPartitions []*ChildPartition `spanner:"child_partitions" json:"child_partitions"`
+}
+
+// ChildPartition is a partition of a change stream.
+type ChildPartition struct {
+	Token                 string   `spanner:"token" json:"token"`
+	ParentPartitionTokens []string `spanner:"parent_partition_tokens" json:"parent_partition_tokens"`
+}
+// modToRow converts the keys and the values of a mod into a row. Only the
+// columns contained in both of the mod and columns are set to the row.
+func (r *DataChangeRecord) modToRow(keys, values spanner.NullJSON, columns []string) (*spanner.Row, []string, error) {
+	types := make(map[string]*spannerpb.Type, len(r.ColumnTypes))
+	for _, ct := range r.ColumnTypes {
+		b, err := json.Marshal(ct.Type.Value)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		var typ spannerpb.Type
+		if err := protojson.Unmarshal(b, &typ); err != nil {
+			return nil, nil, fmt.Errorf("invalid type of column %s: %v", ct.Name, err)
+		}
+		types[ct.Name] = &typ
+	}
+
+	var cols []string
+	var vals []interface{}
+	for _, m := range []spanner.NullJSON{keys, values} {
+		if !m.Valid {
+			continue
+		}
+
+		kv, ok := m.Value.(map[string]interface{})
+		if !ok {
+			return nil, nil, fmt.Errorf("unexpected mod: %v", m.Value)
+		}
+
+		for _, col := range columns {
+			v, ok := kv[col]
+			if !ok {
+				continue
+			}
+
+			typ, ok := types[col]
+			if !ok {
+				return nil, nil, fmt.Errorf("unknown type of column %s", col)
+			}
+
+			val, err := structpb.NewValue(v)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			cols = append(cols, col)
+			vals = append(vals, spanner.GenericColumnValue{Type: typ, Value: val})
+		}
+	}
+
+	row, err := spanner.NewRow(cols, vals)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return row, cols, nil
+}
+{{- range .Schema.Types }}
+{{- if .ChangeStreams }}
+
+// Decode{{ .Name }}Mods decodes the mods of a DataChangeRecord of '{{ .TableName }}'
+// into new values and old values of {{ .Name }} in the same order as the mods.
+// Columns not captured in a mod are left as zero values.
+func (r *DataChangeRecord) Decode{{ .Name }}Mods() ([]*{{ .Name }}, []*{{ .Name }}, error) {
+	if r.TableName != "{{ .TableName }}" {
+		return nil, nil, newErrorWithCode(codes.InvalidArgument, "DataChangeRecord.Decode{{ .Name }}Mods", "{{ .TableName }}",
+			fmt.Errorf("data change record of %s cannot be decoded", r.TableName))
+	}
+
+	decode := func(keys, values spanner.NullJSON) (*{{ .Name }}, error) {
+		row, cols, err := r.modToRow(keys, values, {{ .Name }}Columns())
+		if err != nil {
+			return nil, err
+		}
+
+		return new{{ .Name }}_Decoder(cols)(row)
+	}
+
+	newValues := make([]*{{ .Name }}, 0, len(r.Mods))
+	oldValues := make([]*{{ .Name }}, 0, len(r.Mods))
+	for _, mod := range r.Mods {
+		newValue, err := decode(mod.Keys, mod.NewValues)
+		if err != nil {
+			return nil, nil, newErrorWithCode(codes.Internal, "DataChangeRecord.Decode{{ .Name }}Mods", "{{ .TableName }}", err)
+		}
+		oldValue, err := decode(mod.Keys, mod.OldValues)
+		if err != nil {
+			return nil, nil, newErrorWithCode(codes.Internal, "DataChangeRecord.Decode{{ .Name }}Mods", "{{ .TableName }}", err)
+		}
+
+		newValues = append(newValues, newValue)
+		oldValues = append(oldValues, oldValue)
+	}
+
+	return newValues, oldValues, nil
+}
+{{- end }}
+{{- end }}
+{{- range .Schema.ChangeStreams }}
+
+// Read{{ .Name }}ChangeRecords queries the change stream '{{ .StreamName }}' by READ_{{ .StreamName }}
+// and calls fn for each change record. partitionToken is NULL to query the
+// initial partitions. The query must be run in a single-use read-only transaction.
+func Read{{ .Name }}ChangeRecords(ctx context.Context, db YODB, startTimestamp time.Time, endTimestamp spanner.NullTime, partitionToken spanner.NullString, heartbeat time.Duration, fn func(*ChangeRecord) error) error {
+{{- if isPostgreSQL }}
+	const sqlstr = "SELECT * FROM spanner.{{ escape (printf "read_json_%s" .StreamName) }}($1, $2, $3, $4, NULL)"
+
+	stmt := spanner.NewStatement(sqlstr)
+	stmt.Params["p1"] = startTimestamp
+	stmt.Params["p2"] = endTimestamp
+	stmt.Params["p3"] = partitionToken
+	stmt.Params["p4"] = heartbeat.Milliseconds()
+
+	// run query
+	YOLog(ctx, sqlstr, startTimestamp, endTimestamp, partitionToken, heartbeat)
+	iter := db.Query(ctx, stmt)
+	err := iter.Do(func(row *spanner.Row) error {
+		// each row has a change record as JSONB
+		var record spanner.PGJsonB
+		if err := row.Columns(&record); err != nil {
+			return err
+		}
+
+		b, err := json.Marshal(record.Value)
+		if err != nil {
+			return err
+		}
+
+		var r struct {
+			DataChangeRecord      *DataChangeRecord      `json:"data_change_record"`
+			HeartbeatRecord       *HeartbeatRecord       `json:"heartbeat_record"`
+			ChildPartitionsRecord *ChildPartitionsRecord `json:"child_partitions_record"`
+		}
+		if err := json.Unmarshal(b, &r); err != nil {
+			return err
+		}
+
+		var cr ChangeRecord
+		if r.DataChangeRecord != nil {
+			cr.DataChangeRecord = append(cr.DataChangeRecord, r.DataChangeRecord)
+		}
+		if r.HeartbeatRecord != nil {
+			cr.HeartbeatRecord = append(cr.HeartbeatRecord, r.HeartbeatRecord)
+		}
+		if r.ChildPartitionsRecord != nil {
+			cr.ChildPartitionsRecord = append(cr.ChildPartitionsRecord, r.ChildPartitionsRecord)
+		}
+
+		return fn(&cr)
+	})
+{{- else }}
+	const sqlstr = "SELECT ChangeRecord FROM READ_{{ .StreamName }}(" +
+		"start_timestamp => @startTimestamp, " +
+		"end_timestamp => @endTimestamp, " +
+		"partition_token => @partitionToken, " +
+		"heartbeat_milliseconds => @heartbeatMilliseconds)"
+
+	stmt := spanner.NewStatement(sqlstr)
+	stmt.Params["startTimestamp"] = startTimestamp
+	stmt.Params["endTimestamp"] = endTimestamp
+	stmt.Params["partitionToken"] = partitionToken
+	stmt.Params["heartbeatMilliseconds"] = heartbeat.Milliseconds()
+
+	// run query
+	YOLog(ctx, sqlstr, startTimestamp, endTimestamp, partitionToken, heartbeat)
+	iter := db.Query(ctx, stmt)
+	err := iter.Do(func(row *spanner.Row) error {
+		var records []*ChangeRecord
+		if err := row.Columns(&records); err != nil {
+			return err
+		}
+
+		for _, r := range records {
+			if err := fn(r); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+{{- end }}
+	if err != nil {
+		return newError("Read{{ .Name }}ChangeRecords", "{{ .StreamName }}", err)
+	}
+
+	return nil
+}
+{{- end }}
+{{- end }}
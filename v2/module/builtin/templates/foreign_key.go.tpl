@@ -0,0 +1,90 @@
+{{- range .ForeignKeys }}
+{{- $short := (shortName .Type.Name "err" "sqlstr" "db" "stmt" "iter" "row" "res" "decoder" "YOLog") -}}
+{{- $refShort := (shortName .RefType.Name "err" "sqlstr" "db" "stmt" "iter" "row" "res" "decoder" "YOLog" $short) -}}
+{{- $table := (.Type.TableName) -}}
+{{- $refTable := (.RefType.TableName) -}}
+
+// Find{{ .Name }} retrieves the {{ .RefType.Name }} referenced by the {{ .Type.Name }}.
+//
+// If no row is present, then Find{{ .Name }} returns an error where
+// spanner.ErrCode(err) is codes.NotFound.
+//
+// Generated from foreign key '{{ .ConstraintName }}'.
+func ({{ $short }} *{{ .Type.Name }}) Find{{ .Name }}(ctx context.Context, db YODB) (*{{ .RefType.Name }}, error) {
+	const sqlstr = "SELECT " +
+		"{{ columnNamesWithoutHidden .RefType.Fields }} " +
+		"FROM {{ escapeTable $refTable }} " +
+		"WHERE {{ columnNamesQuery .RefFields " AND " }} LIMIT 1"
+
+	stmt := spanner.NewStatement(sqlstr)
+	{{- range $i, $f := .Fields }}
+	stmt.Params["{{ nthParamName $i }}"] = yoEncode({{ $short }}.{{ $f.Name }})
+	{{- end }}
+
+	decoder := new{{ .RefType.Name }}_Decoder({{ .RefType.Name }}Columns())
+
+	// run query
+	YOLog(ctx, sqlstr{{ range .Fields }}, {{ $short }}.{{ .Name }}{{ end }})
+	iter := db.Query(ctx, stmt)
+	defer iter.Stop()
+
+	row, err := iter.Next()
+	if err != nil {
+		if err == iterator.Done {
+			return nil, newErrorWithCode(codes.NotFound, "{{ .Type.Name }}.Find{{ .Name }}", "{{ $refTable }}", err)
+		}
+		return nil, newError("{{ .Type.Name }}.Find{{ .Name }}", "{{ $refTable }}", err)
+	}
+
+	{{ $refShort }}, err := decoder(row)
+	if err != nil {
+		return nil, newErrorWithCode(codes.Internal, "{{ .Type.Name }}.Find{{ .Name }}", "{{ $refTable }}", err)
+	}
+
+	return {{ $refShort }}, nil
+}
+
+// Find{{ pluralize .Type.Name }}By{{ .Name }} retrieves multiple rows from '{{ $table }}'
+// referencing the {{ .RefType.Name }} as a slice of {{ .Type.Name }}.
+//
+// Generated from foreign key '{{ .ConstraintName }}'.
+func Find{{ pluralize .Type.Name }}By{{ .Name }}(ctx context.Context, db YODB, {{ $refShort }} *{{ .RefType.Name }}) ([]*{{ .Type.Name }}, error) {
+	const sqlstr = "SELECT " +
+		"{{ columnNamesWithoutHidden .Type.Fields }} " +
+		"FROM {{ escapeTable $table }} " +
+		"WHERE {{ columnNamesQuery .Fields " AND " }}"
+
+	stmt := spanner.NewStatement(sqlstr)
+	{{- range $i, $f := .RefFields }}
+	stmt.Params["{{ nthParamName $i }}"] = yoEncode({{ $refShort }}.{{ $f.Name }})
+	{{- end }}
+
+	decoder := new{{ .Type.Name }}_Decoder({{ .Type.Name }}Columns())
+
+	// run query
+	YOLog(ctx, sqlstr{{ range .RefFields }}, {{ $refShort }}.{{ .Name }}{{ end }})
+	iter := db.Query(ctx, stmt)
+	defer iter.Stop()
+
+	// load results
+	res := []*{{ .Type.Name }}{}
+	for {
+		row, err := iter.Next()
+		if err != nil {
+			if err == iterator.Done {
+				break
+			}
+			return nil, newError("Find{{ pluralize .Type.Name }}By{{ .Name }}", "{{ $table }}", err)
+		}
+
+		{{ $short }}, err := decoder(row)
+		if err != nil {
+			return nil, newErrorWithCode(codes.Internal, "Find{{ pluralize .Type.Name }}By{{ .Name }}", "{{ $table }}", err)
+		}
+
+		res = append(res, {{ $short }})
+	}
+
+	return res, nil
+}
+{{- end }}
@@ -1,4 +1,5 @@
 {{- range .Indexes }}
+{{- if eq .IndexType "INDEX" }}
 {{- $short := (shortName .Type.Name "err" "sqlstr" "db" "q" "res" "YOLog" .Fields) -}}
 {{- $table := (.Type.TableName) -}}
 
@@ -6,6 +7,9 @@
 // Find{{ .FuncName }} retrieves multiple rows from '{{ $table }}' as a slice of {{ .Type.Name }}.
 //
 // Generated from index '{{ .IndexName }}'.
+{{- if and .NullFiltered .NullableFields }}
+// A NULL value never matches since the index is null filtered.
+{{- end }}
 func Find{{ .FuncName }}(ctx context.Context, db YODB{{ goParams .Fields true true }}) ([]*{{ .Type.Name }}, error) {
 {{- else }}
 // Find{{ .FuncName }} retrieves a row from '{{ $table }}' as a {{ .Type.Name }}.
@@ -14,36 +18,45 @@ func Find{{ .FuncName }}(ctx context.Context, db YODB{{ goParams .Fields true tr
 // spanner.ErrCode(err) is codes.NotFound.
 //
 // Generated from unique index '{{ .IndexName }}'.
+{{- if and .NullFiltered .NullableFields }}
+// A NULL value never matches since the index is null filtered.
+{{- end }}
 func Find{{ .FuncName }}(ctx context.Context, db YODB{{ goParams .Fields true true }}) (*{{ .Type.Name }}, error) {
 {{- end }}
-	{{- if not .NullableFields }}
+	{{- if or .NullFiltered (not .NullableFields) }}
 	const sqlstr = "SELECT " +
 		"{{ columnNamesWithoutHidden .Type.Fields }} " +
-		"FROM {{ $table }}@{FORCE_INDEX={{ .IndexName }}} " +
+		"FROM {{ escapeTable $table }}{{ forceIndex .IndexName }} " +
 		"WHERE {{ columnNamesQuery .Fields " AND " }}"
+		{{- if and (not .IsUnique) .Type.PrimaryKeys }} +
+		" ORDER BY {{ indexOrderBy .Type.PrimaryKeys }}"
+		{{- end }}
 	{{- else }}
 	var sqlstr = "SELECT " +
 		"{{ columnNamesWithoutHidden .Type.Fields }} " +
-		"FROM {{ $table }}@{FORCE_INDEX={{ .IndexName }}} "
+		"FROM {{ escapeTable $table }}{{ forceIndex .IndexName }} "
 
 	conds := make([]string, {{ len .Fields }})
 	{{- range $i, $f := .Fields }}
 	{{- if $f.IsNotNull }}
-		conds[{{ $i }}] = "{{ escape $f.ColumnName }} = @param{{ $i }}"
+		conds[{{ $i }}] = "{{ escape $f.ColumnName }} = {{ nthParam $i }}"
 	{{- else }}
 	if {{ nullcheck $f }} {
 		conds[{{ $i }}] = "{{ escape $f.ColumnName }} IS NULL"
 	} else {
-		conds[{{ $i }}] = "{{ escape $f.ColumnName }} = @param{{ $i }}"
+		conds[{{ $i }}] = "{{ escape $f.ColumnName }} = {{ nthParam $i }}"
 	}
 	{{- end }}
 	{{- end }}
 	sqlstr += "WHERE " + strings.Join(conds, " AND ")
+	{{- if and (not .IsUnique) .Type.PrimaryKeys }}
+	sqlstr += " ORDER BY {{ indexOrderBy .Type.PrimaryKeys }}"
+	{{- end }}
 	{{- end }}
 
 	stmt := spanner.NewStatement(sqlstr)
 	{{- range $i, $f := .Fields }}
-		stmt.Params["param{{ $i }}"] = {{ goEncodedParam $f.Name }}
+		stmt.Params["{{ nthParamName $i }}"] = {{ goEncodedParam $f.Name }}
 	{{- end}}
 
 
@@ -137,3 +150,4 @@ func Read{{ .FuncName }}(ctx context.Context, db YODB, keys spanner.KeySet) ([]*
     return res, nil
 }
 {{- end }}
+{{- end }}
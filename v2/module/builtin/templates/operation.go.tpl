@@ -1,35 +1,133 @@
 {{- $short := (shortName .Name "err" "res" "sqlstr" "db" "YOLog") -}}
 {{- $table := (.TableName) -}}
+{{- $validate := validateMutations -}}
+
+{{- if .IsView }}
+// Query{{ .Name }} retrieves rows from the view '{{ $table }}' as a slice.
+// where is a condition of WHERE clause with the query parameters used in it,
+// such as spanner.Statement{SQL: "Name = @name", Params: params}. where.SQL
+// must be a constant, and values must be passed by where.Params instead of
+// being formatted into where.SQL. All rows are retrieved if where.SQL is empty.
+func Query{{ .Name }}(ctx context.Context, db YODB, where spanner.Statement) ([]*{{ .Name }}, error) {
+	sqlstr := "SELECT " +
+		"{{ columnNamesWithoutHidden .Fields }} " +
+		"FROM {{ escapeTable $table }}"
+	if where.SQL != "" {
+		sqlstr += " WHERE " + where.SQL
+	}
+
+	stmt := spanner.Statement{SQL: sqlstr, Params: where.Params}
+
+	decoder := new{{ .Name }}_Decoder({{ .Name }}Columns())
+
+	// run query
+	YOLog(ctx, sqlstr, where.Params)
+	iter := db.Query(ctx, stmt)
+	defer iter.Stop()
+
+	// load results
+	res := []*{{ .Name }}{}
+	for {
+		row, err := iter.Next()
+		if err != nil {
+			if err == iterator.Done {
+				break
+			}
+			return nil, newError("Query{{ .Name }}", "{{ $table }}", err)
+		}
+
+		{{ $short }}, err := decoder(row)
+		if err != nil {
+			return nil, newErrorWithCode(codes.Internal, "Query{{ .Name }}", "{{ $table }}", err)
+		}
+
+		res = append(res, {{ $short }})
+	}
+
+	return res, nil
+}
+{{- else }}
 
 // Insert returns a Mutation to insert a row into a table. If the row already
 // exists, the write or transaction fails.
-func ({{ $short }} *{{ .Name }}) Insert(ctx context.Context) *spanner.Mutation {
+func ({{ $short }} *{{ .Name }}) Insert(ctx context.Context) {{ if $validate }}(*spanner.Mutation, error){{ else }}*spanner.Mutation{{ end }} {
+{{- if $validate }}
+	if err := {{ $short }}.Validate(); err != nil {
+		return nil, err
+	}
+{{ end }}
 	values, _ := {{ $short }}.columnsToValues({{ .Name }}WritableColumns())
-	return spanner.Insert("{{ $table }}", {{ .Name }}WritableColumns(), values)
+	return spanner.Insert("{{ $table }}", {{ .Name }}WritableColumns(), values){{ if $validate }}, nil{{ end }}
 }
 
+{{- $hasDefault := false }}
+{{- range .Fields }}
+	{{- if .HasDefault }}
+		{{- $hasDefault = true }}
+	{{- end }}
+{{- end }}
+{{- if $hasDefault }}
+
+// InsertWithDefaults returns a Mutation to insert a row into a table. Columns
+// with a DEFAULT expression are left out of the mutation so that Spanner
+// applies the default values. If the row already exists, the write or
+// transaction fails.
+func ({{ $short }} *{{ .Name }}) InsertWithDefaults(ctx context.Context) {{ if $validate }}(*spanner.Mutation, error){{ else }}*spanner.Mutation{{ end }} {
+	cols := []string{
+{{- range .Fields }}
+	{{- if not (or .IsGenerated .HasDefault) }}
+		"{{ .ColumnName }}",
+	{{- end }}
+{{- end }}
+	}
+{{- if $validate }}
+
+	if err := {{ $short }}.validateColumns(cols); err != nil {
+		return nil, err
+	}
+{{ end }}
+	values, _ := {{ $short }}.columnsToValues(cols)
+	return spanner.Insert("{{ $table }}", cols, values){{ if $validate }}, nil{{ end }}
+}
+{{- end }}
+
 {{ if ne (len .Fields) (len .PrimaryKeyFields) }}
 // Update returns a Mutation to update a row in a table. If the row does not
 // already exist, the write or transaction fails.
-func ({{ $short }} *{{ .Name }}) Update(ctx context.Context) *spanner.Mutation {
+func ({{ $short }} *{{ .Name }}) Update(ctx context.Context) {{ if $validate }}(*spanner.Mutation, error){{ else }}*spanner.Mutation{{ end }} {
+{{- if $validate }}
+	if err := {{ $short }}.Validate(); err != nil {
+		return nil, err
+	}
+{{ end }}
 	values, _ := {{ $short }}.columnsToValues({{ .Name }}WritableColumns())
-	return spanner.Update("{{ $table }}", {{ .Name }}WritableColumns(), values)
+	return spanner.Update("{{ $table }}", {{ .Name }}WritableColumns(), values){{ if $validate }}, nil{{ end }}
 }
 
 // InsertOrUpdate returns a Mutation to insert a row into a table. If the row
 // already exists, it updates it instead. Any column values not explicitly
 // written are preserved.
-func ({{ $short }} *{{ .Name }}) InsertOrUpdate(ctx context.Context) *spanner.Mutation {
+func ({{ $short }} *{{ .Name }}) InsertOrUpdate(ctx context.Context) {{ if $validate }}(*spanner.Mutation, error){{ else }}*spanner.Mutation{{ end }} {
+{{- if $validate }}
+	if err := {{ $short }}.Validate(); err != nil {
+		return nil, err
+	}
+{{ end }}
 	values, _ := {{ $short }}.columnsToValues({{ .Name }}WritableColumns())
-	return spanner.InsertOrUpdate("{{ $table }}", {{ .Name }}WritableColumns(), values)
+	return spanner.InsertOrUpdate("{{ $table }}", {{ .Name }}WritableColumns(), values){{ if $validate }}, nil{{ end }}
 }
 
 // Replace returns a Mutation to insert a row into a table, deleting any
 // existing row. Unlike InsertOrUpdate, this means any values not explicitly
 // written become NULL.
-func ({{ $short }} *{{ .Name }}) Replace(ctx context.Context) *spanner.Mutation {
+func ({{ $short }} *{{ .Name }}) Replace(ctx context.Context) {{ if $validate }}(*spanner.Mutation, error){{ else }}*spanner.Mutation{{ end }} {
+{{- if $validate }}
+	if err := {{ $short }}.Validate(); err != nil {
+		return nil, err
+	}
+{{ end }}
 	values, _ := {{ $short }}.columnsToValues({{ .Name }}WritableColumns())
-	return spanner.Replace("{{ $table }}", {{ .Name }}WritableColumns(), values)
+	return spanner.Replace("{{ $table }}", {{ .Name }}WritableColumns(), values){{ if $validate }}, nil{{ end }}
 }
 
 // UpdateColumns returns a Mutation to update specified columns of a row in a table.
@@ -91,3 +189,38 @@ func ({{ $short }} *{{ .Name }}) Delete(ctx context.Context) *spanner.Mutation {
 	values, _ := {{ $short }}.columnsToValues({{ .Name }}PrimaryKeys())
 	return spanner.Delete("{{ $table }}", spanner.Key(values))
 }
+{{- if .Parent }}
+
+// Read{{ .Name }}By{{ .Parent.Name }}Key retrieves all rows of {{ .Name }} interleaved
+// in the {{ .Parent.Name }} row identified by the primary key.
+func Read{{ .Name }}By{{ .Parent.Name }}Key(ctx context.Context, db YODB{{ goParams .Parent.PrimaryKeyFields true true }}) ([]*{{ .Name }}, error) {
+	var res []*{{ .Name }}
+
+	decoder := new{{ .Name }}_Decoder({{ .Name }}Columns())
+
+	_key := spanner.Key{ {{ goEncodedParams .Parent.PrimaryKeyFields false }} }
+	rows := db.Read(ctx, "{{ $table }}", _key.AsPrefix(), {{ .Name }}Columns())
+	err := rows.Do(func(row *spanner.Row) error {
+		{{ $short }}, err := decoder(row)
+		if err != nil {
+			return err
+		}
+		res = append(res, {{ $short }})
+
+		return nil
+	})
+	if err != nil {
+		return nil, newErrorWithCode(codes.Internal, "Read{{ .Name }}By{{ .Parent.Name }}Key", "{{ $table }}", err)
+	}
+
+	return res, nil
+}
+
+// Delete{{ .Name }}By{{ .Parent.Name }}Key returns a Mutation to delete all rows of
+// {{ .Name }} interleaved in the {{ .Parent.Name }} row identified by the primary key.
+func Delete{{ .Name }}By{{ .Parent.Name }}Key(ctx context.Context{{ goParams .Parent.PrimaryKeyFields true true }}) *spanner.Mutation {
+	_key := spanner.Key{ {{ goEncodedParams .Parent.PrimaryKeyFields false }} }
+	return spanner.Delete("{{ $table }}", _key.AsPrefix())
+}
+{{- end }}
+{{- end }}
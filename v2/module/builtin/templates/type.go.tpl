@@ -1,18 +1,26 @@
 {{- $short := (shortName .Name "err" "res" "sqlstr" "db" "YOLog") -}}
 {{- $table := (.TableName) -}}
+{{- with protoImports .Fields }}
+
+import (
+{{- range . }}
+	{{ . }}
+{{- end }}
+)
+{{- end }}
 
 // {{ .Name }} represents a row from '{{ $table }}'.
 type {{ .Name }} struct {
 {{- range .Fields }}
 {{- if .IsHidden }}
-{{- else if eq (.SpannerDataType) (.ColumnName) }}
-	{{ .Name }} string `spanner:"{{ .ColumnName }}" json:"{{ .ColumnName }}"` // {{ .ColumnName }} enum
 {{- else }}
-	{{ .Name }} {{ .Type }} `spanner:"{{ .ColumnName }}" json:"{{ .ColumnName }}"` // {{ .ColumnName }}
+	{{ .Name }} {{ .Type }} `{{ structTag .Tags }}` // {{ .ColumnName }}
 {{- end }}
 {{- end }}
 }
 
+{{- if not .IsView }}
+
 func {{ .Name }}PrimaryKeys() []string {
      return []string{
 {{- range .PrimaryKeyFields }}
@@ -20,6 +28,7 @@ func {{ .Name }}PrimaryKeys() []string {
 {{- end }}
 	}
 }
+{{- end }}
 
 func {{ .Name }}Columns() []string {
 	return []string{
@@ -31,6 +40,8 @@ func {{ .Name }}Columns() []string {
 	}
 }
 
+{{- if not .IsView }}
+
 func {{ .Name }}WritableColumns() []string {
 	return []string{
 {{- range .Fields }}
@@ -40,6 +51,36 @@ func {{ .Name }}WritableColumns() []string {
 {{- end }}
 	}
 }
+{{- end }}
+
+{{- with .RowDeletionPolicy }}
+
+// {{ $.Name }}RowDeletionPolicy returns the row deletion policy of '{{ $table }}'.
+func {{ $.Name }}RowDeletionPolicy() YORowDeletionPolicy {
+	return YORowDeletionPolicy{
+		Column:  "{{ .Field.ColumnName }}",
+		NumDays: {{ .NumDays }},
+	}
+}
+
+{{- $column := printf "%s.%s" $short .Field.Name }}
+{{- if ne .Field.Type .Field.OriginalType }}
+	{{- $column = printf "%s(%s)" .Field.OriginalType $column }}
+{{- end }}
+
+// IsExpired reports whether the row is older than the row deletion policy at now.
+// Spanner deletes such a row in the background, so it may still be read for a while.
+func ({{ $short }} *{{ $.Name }}) IsExpired(now time.Time) bool {
+{{- if .Field.IsNotNull }}
+	return now.After({{ $column }}.Add({{ .NumDays }} * 24 * time.Hour))
+{{- else }}
+	if !{{ $column }}.Valid {
+		return false
+	}
+	return now.After({{ $column }}.Time.Add({{ .NumDays }} * 24 * time.Hour))
+{{- end }}
+}
+{{- end }}
 
 func ({{ $short }} *{{ .Name }}) columnsToPtrs(cols []string) ([]interface{}, error) {
 	ret := make([]interface{}, 0, len(cols))
@@ -48,7 +89,11 @@ func ({{ $short }} *{{ .Name }}) columnsToPtrs(cols []string) ([]interface{}, er
 {{- range .Fields }}
 	{{- if not .IsHidden }}
 		case "{{ .ColumnName }}":
+		{{- if and (nullableProto .) (eq .Type .OriginalType) }}
+			ret = append(ret, &yoNullableProtoDecoder{ptr: &{{ $short }}.{{ .Name }}})
+		{{- else }}
 			ret = append(ret, yoDecode(&{{ $short }}.{{ .Name }}))
+		{{- end }}
 	{{- end }}
 {{- end }}
 		default:
@@ -58,12 +103,18 @@ func ({{ $short }} *{{ .Name }}) columnsToPtrs(cols []string) ([]interface{}, er
 	return ret, nil
 }
 
+{{- if not .IsView }}
+
 func ({{ $short }} *{{ .Name }}) columnsToValues(cols []string) ([]interface{}, error) {
 	ret := make([]interface{}, 0, len(cols))
 	for _, col := range cols {
 		switch col {
 {{- range .Fields }}
-	{{- if not .IsHidden }}
+	{{- if .IsHidden }}
+	{{- else if .UseCommitTimestamp }}
+		case "{{ .ColumnName }}":
+			ret = append(ret, spanner.CommitTimestamp)
+	{{- else }}
 		case "{{ .ColumnName }}":
 			ret = append(ret, yoEncode({{ $short }}.{{ .Name }}))
 	{{- end }}
@@ -75,6 +126,7 @@ func ({{ $short }} *{{ .Name }}) columnsToValues(cols []string) ([]interface{},
 
 	return ret, nil
 }
+{{- end }}
 
 // new{{ .Name }}_Decoder returns a decoder which reads a row from *spanner.Row
 // into {{ .Name }}. The decoder is not goroutine-safe. Don't use it concurrently.
@@ -0,0 +1,90 @@
+{{- $short := (shortName .Name "err" "res" "sqlstr" "db" "YOLog") -}}
+{{- $table := (.TableName) -}}
+{{- $type := . -}}
+{{- if not .IsView }}
+{{- $validated := false }}
+{{- range .Fields }}
+{{- if not (or .IsHidden .IsGenerated .UseCommitTimestamp) }}
+{{- if or (and .IsNotNull (or (hasPrefix .Type "spanner.Null") (hasPrefix .Type "spanner.PG") (hasPrefix .Type "[]") (and .ProtoKind (hasPrefix .Type "*")))) (and (gt .Len 0) (eq .Type .OriginalType) (eq .Type "string" "spanner.NullString" "[]byte" "[]string" "[][]byte")) }}
+{{- $validated = true }}
+{{- end }}
+{{- end }}
+{{- end }}
+{{- range .CheckConstraints }}
+{{- if goCheckExpr $short $type . }}
+{{- $validated = true }}
+{{- end }}
+{{- end }}
+// Validate checks the values of {{ .Name }} against the NOT NULL, the length
+// and the CHECK constraints of '{{ $table }}' before writing them. CHECK
+// constraints that cannot be translated into Go are left to Spanner.
+func ({{ $short }} *{{ .Name }}) Validate() error {
+	return {{ $short }}.validateColumns({{ .Name }}WritableColumns())
+}
+
+// validateColumns checks the values of the columns in cols in the same way as
+// Validate. The constraints on the other columns, such as the columns left to
+// their DEFAULT values, are not checked.
+func ({{ $short }} *{{ .Name }}) validateColumns(cols []string) error {
+{{- if $validated }}
+	written := make(map[string]bool, len(cols))
+	for _, col := range cols {
+		written[col] = true
+	}
+{{ end }}
+{{- range .Fields }}
+{{- if or .IsHidden .IsGenerated .UseCommitTimestamp }}
+{{- else if or (and .IsNotNull (or (hasPrefix .Type "spanner.Null") (hasPrefix .Type "spanner.PG") (hasPrefix .Type "[]") (and .ProtoKind (hasPrefix .Type "*")))) (and (gt .Len 0) (eq .Type .OriginalType) (eq .Type "string" "spanner.NullString" "[]byte" "[]string" "[][]byte")) }}
+	if written["{{ .ColumnName }}"] {
+{{- if and .IsNotNull (or (hasPrefix .Type "spanner.Null") (hasPrefix .Type "spanner.PG")) }}
+		if !{{ $short }}.{{ .Name }}.Valid {
+			return newErrorWithCode(codes.InvalidArgument, "{{ $type.Name }}.Validate", "{{ $table }}", fmt.Errorf("{{ .ColumnName }} must not be NULL"))
+		}
+{{- else if and .IsNotNull (or (hasPrefix .Type "[]") (and .ProtoKind (hasPrefix .Type "*"))) }}
+		if {{ $short }}.{{ .Name }} == nil {
+			return newErrorWithCode(codes.InvalidArgument, "{{ $type.Name }}.Validate", "{{ $table }}", fmt.Errorf("{{ .ColumnName }} must not be NULL"))
+		}
+{{- end }}
+{{- if and (gt .Len 0) (eq .Type .OriginalType) }}
+{{- if eq .Type "string" }}
+		if utf8.RuneCountInString({{ $short }}.{{ .Name }}) > {{ .Len }} {
+			return newErrorWithCode(codes.InvalidArgument, "{{ $type.Name }}.Validate", "{{ $table }}", fmt.Errorf("{{ .ColumnName }} must be at most {{ .Len }} characters"))
+		}
+{{- else if eq .Type "spanner.NullString" }}
+		if {{ $short }}.{{ .Name }}.Valid && utf8.RuneCountInString({{ $short }}.{{ .Name }}.StringVal) > {{ .Len }} {
+			return newErrorWithCode(codes.InvalidArgument, "{{ $type.Name }}.Validate", "{{ $table }}", fmt.Errorf("{{ .ColumnName }} must be at most {{ .Len }} characters"))
+		}
+{{- else if eq .Type "[]byte" }}
+		if len({{ $short }}.{{ .Name }}) > {{ .Len }} {
+			return newErrorWithCode(codes.InvalidArgument, "{{ $type.Name }}.Validate", "{{ $table }}", fmt.Errorf("{{ .ColumnName }} must be at most {{ .Len }} bytes"))
+		}
+{{- else if eq .Type "[]string" }}
+		for _, elem := range {{ $short }}.{{ .Name }} {
+			if utf8.RuneCountInString(elem) > {{ .Len }} {
+				return newErrorWithCode(codes.InvalidArgument, "{{ $type.Name }}.Validate", "{{ $table }}", fmt.Errorf("elements of {{ .ColumnName }} must be at most {{ .Len }} characters"))
+			}
+		}
+{{- else if eq .Type "[][]byte" }}
+		for _, elem := range {{ $short }}.{{ .Name }} {
+			if len(elem) > {{ .Len }} {
+				return newErrorWithCode(codes.InvalidArgument, "{{ $type.Name }}.Validate", "{{ $table }}", fmt.Errorf("elements of {{ .ColumnName }} must be at most {{ .Len }} bytes"))
+			}
+		}
+{{- end }}
+{{- end }}
+	}
+{{- end }}
+{{- end }}
+{{- range .CheckConstraints }}
+{{- $cond := goCheckExpr $short $type . }}
+{{- if $cond }}
+	if {{ range checkColumns $type . }}written["{{ . }}"] && {{ end }}!({{ $cond }}) {
+		return newErrorWithCode(codes.InvalidArgument, "{{ $type.Name }}.Validate", "{{ $table }}", fmt.Errorf("check constraint %s is violated", {{ if .Name }}{{ printf "%q" .Name }}{{ else }}{{ printf "%q" .Expr }}{{ end }}))
+	}
+{{- else }}
+	// CHECK {{ printf "%q" .Expr }} is left to Spanner
+{{- end }}
+{{- end }}
+	return nil
+}
+{{- end }}
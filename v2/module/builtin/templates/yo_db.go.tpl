@@ -1,3 +1,7 @@
+{{- if hasNullableProto .Schema }}
+import "google.golang.org/protobuf/proto"
+
+{{ end -}}
 // YODB is the common interface for database operations.
 type YODB interface {
 	ReadRow(ctx context.Context, table string, key spanner.Key, columns []string) (*spanner.Row, error)
@@ -58,6 +62,20 @@ func (e yoError) Timeout() bool { return e.code == codes.DeadlineExceeded }
 func (e yoError) Temporary() bool { return e.code == codes.DeadlineExceeded }
 func (e yoError) NotFound() bool { return e.code == codes.NotFound }
 
+var yoRegexps sync.Map
+
+// yoRegexp returns the compiled regular expression of pattern. It is used to
+// validate REGEXP_CONTAINS in CHECK constraints, and the result is cached.
+func yoRegexp(pattern string) *regexp.Regexp {
+	if re, ok := yoRegexps.Load(pattern); ok {
+		return re.(*regexp.Regexp)
+	}
+
+	re := regexp.MustCompile(pattern)
+	yoRegexps.Store(pattern, re)
+	return re
+}
+
 // yoEncode encodes primitive types that spanner library does not support into spanner types before
 // passing to spanner functions. Suppotted primitive types and user defined types that implement
 // spanner.Encoder interface are handled in encoding phase inside spanner libirary.
@@ -131,3 +149,60 @@ func (y *yoPrimitiveDecoder) DecodeSpanner(val interface{}) error {
 
 	return nil
 }
+{{- if hasNullableProto .Schema }}
+
+// yoNullableProtoDecoder decodes a nullable PROTO or ENUM column into ptr,
+// which is a pointer to a pointer of a proto message or enum. It sets nil for
+// NULL since the spanner library cannot decode NULL into them.
+type yoNullableProtoDecoder struct {
+	ptr interface{}
+}
+
+func (y *yoNullableProtoDecoder) DecodeSpanner(val interface{}) error {
+	rv := reflect.ValueOf(y.ptr).Elem()
+
+	var strVal string
+	switch v := val.(type) {
+	case string:
+		strVal = v
+	case *string:
+		if v == nil {
+			rv.Set(reflect.Zero(rv.Type()))
+			return nil
+		}
+		strVal = *v
+	default:
+		return spanner.ToSpannerError(status.Errorf(codes.FailedPrecondition, "failed to decode proto field: %T(%v)", val, val))
+	}
+
+	pv := reflect.New(rv.Type().Elem())
+	switch m := pv.Interface().(type) {
+	case proto.Message:
+		b, err := base64.StdEncoding.DecodeString(strVal)
+		if err != nil {
+			return spanner.ToSpannerError(status.Errorf(codes.FailedPrecondition, "%v wasn't correctly encoded: <%v>", val, err))
+		}
+		if err := proto.Unmarshal(b, m); err != nil {
+			return spanner.ToSpannerError(status.Errorf(codes.FailedPrecondition, "failed to unmarshal %T: %v", m, err))
+		}
+	default:
+		intVal, err := strconv.ParseInt(strVal, 10, 32)
+		if err != nil {
+			return spanner.ToSpannerError(status.Errorf(codes.FailedPrecondition, "%v wasn't correctly encoded: <%v>", val, err))
+		}
+		pv.Elem().SetInt(intVal)
+	}
+
+	rv.Set(pv)
+	return nil
+}
+{{- end }}
+{{- if hasRowDeletionPolicy .Schema }}
+
+// YORowDeletionPolicy is a row deletion policy (TTL) of a table. Spanner
+// deletes a row in the background after its Column is older than NumDays days.
+type YORowDeletionPolicy struct {
+	Column  string
+	NumDays int64
+}
+{{- end }}
@@ -0,0 +1,39 @@
+// Copyright (c) 2020 Mercari, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+// Package plugin defines the protocol of the external generator plugins of yo.
+//
+// A plugin is an executable. yo writes a Request with the schema to its stdin
+// as JSON, and the plugin writes a Response with the generated files to its
+// stdout as JSON. yo puts the header of the header module on the files,
+// formats them and writes them under the output directory in the same way as
+// the code generated by the templates.
+//
+// A plugin written in Go can use Main:
+//
+//	func main() {
+//		plugin.Main(func(req *plugin.Request) (*plugin.Response, error) {
+//			schema, err := req.Schema.Models()
+//			if err != nil {
+//				return nil, err
+//			}
+//			...
+//		})
+//	}
+package plugin // import "go.mercari.io/yo/v2/plugin"
@@ -0,0 +1,143 @@
+// Copyright (c) 2020 Mercari, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package plugin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path"
+	"strings"
+)
+
+// Version is the version of the plugin protocol.
+const Version = 1
+
+// Request is the request written to the stdin of a plugin.
+type Request struct {
+	Version int // version of the protocol
+	Schema  *Schema
+	Options *Options
+}
+
+// Options is the options of the generate command.
+type Options struct {
+	PackageName    string   // package name of the generated code
+	BuildTag       string   // build tags of the generated code
+	FilenameSuffix string   // suffix of the file names such as .yo.go
+	Parameters     []string // values of --plugin-opt
+}
+
+// Response is the response written to the stdout by a plugin.
+type Response struct {
+	Files []*File
+
+	// Error is the error of the plugin such as an unsupported schema. The
+	// generate command fails with it.
+	Error string
+}
+
+// File is a file generated by a plugin.
+type File struct {
+	// Filename is the name of the file under the output directory such as
+	// singer_repository.yo.go.
+	Filename string
+
+	// Content is the Go code following the header, which has the package
+	// clause. The imports in it are merged into the imports of the header.
+	Content string
+}
+
+// Main runs a plugin with fn. It reads a request from stdin and writes the
+// response of fn to stdout. The error of fn is returned to yo by the response.
+func Main(fn func(req *Request) (*Response, error)) {
+	if err := Serve(os.Stdin, os.Stdout, fn); err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		os.Exit(1)
+	}
+}
+
+// Serve reads a request from r, and writes the response of fn to w.
+func Serve(r io.Reader, w io.Writer, fn func(req *Request) (*Response, error)) error {
+	var req Request
+	if err := json.NewDecoder(r).Decode(&req); err != nil {
+		return fmt.Errorf("failed to read request: %v", err)
+	}
+
+	var resp *Response
+	if req.Version != Version {
+		resp = &Response{Error: fmt.Sprintf("unsupported protocol version %d: the plugin supports %d", req.Version, Version)}
+	} else {
+		var err error
+		resp, err = fn(&req)
+		if err != nil {
+			resp = &Response{Error: err.Error()}
+		}
+	}
+
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		return fmt.Errorf("failed to write response: %v", err)
+	}
+
+	return nil
+}
+
+// Run runs the plugin of the path with the request, and returns its response.
+// The stderr of the plugin is written to stderr. It returns an error if the
+// plugin fails or the response has an error or an invalid file name. The
+// plugin is killed when ctx is done.
+func Run(ctx context.Context, plugin string, req *Request, stderr io.Writer) (*Response, error) {
+	b, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode request for plugin %s: %v", plugin, err)
+	}
+
+	stdout := new(bytes.Buffer)
+	cmd := exec.CommandContext(ctx, plugin)
+	cmd.Stdin = bytes.NewReader(b)
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+	if err := cmd.Run(); err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			err = ctxErr
+		}
+		return nil, fmt.Errorf("plugin %s failed: %v", plugin, err)
+	}
+
+	var resp Response
+	if err := json.Unmarshal(stdout.Bytes(), &resp); err != nil {
+		return nil, fmt.Errorf("invalid response of plugin %s: %v", plugin, err)
+	}
+	if resp.Error != "" {
+		return nil, fmt.Errorf("plugin %s: %s", plugin, resp.Error)
+	}
+
+	for _, f := range resp.Files {
+		if f.Filename == "" || path.Base(f.Filename) != f.Filename || strings.Contains(f.Filename, `\`) || path.Ext(f.Filename) != ".go" {
+			return nil, fmt.Errorf("plugin %s returns invalid file name %q: it must be a .go file name without a directory", plugin, f.Filename)
+		}
+	}
+
+	return &resp, nil
+}
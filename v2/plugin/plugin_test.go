@@ -0,0 +1,84 @@
+// Copyright (c) 2020 Mercari, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package plugin
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestServe(t *testing.T) {
+	table := map[string]struct {
+		req      string
+		fn       func(req *Request) (*Response, error)
+		expected *Response
+	}{
+		"Files": {
+			req: `{"Version":1,"Schema":{"Types":[{"Name":"Singer","TableName":"Singers"}]},"Options":{"PackageName":"models"}}`,
+			fn: func(req *Request) (*Response, error) {
+				return &Response{
+					Files: []*File{
+						{Filename: "singer_repository.yo.go", Content: "// " + req.Options.PackageName + "." + req.Schema.Types[0].Name},
+					},
+				}, nil
+			},
+			expected: &Response{
+				Files: []*File{{Filename: "singer_repository.yo.go", Content: "// models.Singer"}},
+			},
+		},
+		"Error": {
+			req: `{"Version":1,"Schema":{},"Options":{}}`,
+			fn: func(req *Request) (*Response, error) {
+				return nil, fmt.Errorf("no tables")
+			},
+			expected: &Response{Error: "no tables"},
+		},
+		"UnsupportedVersion": {
+			req: `{"Version":2,"Schema":{},"Options":{}}`,
+			fn: func(req *Request) (*Response, error) {
+				t.Fatal("unexpected call")
+				return nil, nil
+			},
+			expected: &Response{Error: "unsupported protocol version 2: the plugin supports 1"},
+		},
+	}
+
+	for name, tc := range table {
+		t.Run(name, func(t *testing.T) {
+			w := new(bytes.Buffer)
+			if err := Serve(strings.NewReader(tc.req), w, tc.fn); err != nil {
+				t.Fatalf("failed to serve: %v", err)
+			}
+
+			var got Response
+			if err := json.Unmarshal(w.Bytes(), &got); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if diff := cmp.Diff(&got, tc.expected); diff != "" {
+				t.Errorf("(-got, +want)\n%s", diff)
+			}
+		})
+	}
+}
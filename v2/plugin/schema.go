@@ -0,0 +1,381 @@
+// Copyright (c) 2020 Mercari, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package plugin
+
+import (
+	"fmt"
+	"sort"
+
+	"go.mercari.io/yo/v2/models"
+)
+
+// Schema is models.Schema without the cyclic references. The types are
+// referred by the table names, and the fields by the column names.
+type Schema struct {
+	Dialect       string
+	Types         []*Type
+	ChangeStreams []*ChangeStream
+}
+
+// Type is models.Type. Children and ChangeStreams are restored by Models from
+// Parent of the types and Tables of the change streams.
+type Type struct {
+	Name              string
+	TableName         string
+	Schema            string
+	PrimaryKeys       []*IndexKey // primary key columns in order with the sort direction
+	Fields            []*models.Field
+	Indexes           []*Index
+	Parent            string // table name of the parent. Empty if the table is not interleaved
+	InterleaveType    string
+	OnDeleteAction    string
+	ForeignKeys       []*ForeignKey
+	IsView            bool
+	CheckConstraints  []*models.CheckConstraint
+	RowDeletionPolicy *RowDeletionPolicy
+}
+
+// Index is models.Index.
+type Index struct {
+	Name           string
+	FuncName       string
+	LegacyFuncName string
+	Fields         []string // column names
+	StoringFields  []string // column names
+	NullableFields []string // column names
+	Keys           []*IndexKey
+	IndexName      string
+	IsUnique       bool
+	IsPrimary      bool
+	NullFiltered   bool
+	InterleavedIn  string
+	IndexType      string
+}
+
+// IndexKey is models.IndexKey.
+type IndexKey struct {
+	Column string
+	Desc   bool
+}
+
+// ForeignKey is models.ForeignKey.
+type ForeignKey struct {
+	Name           string
+	ConstraintName string
+	Columns        []string // referencing columns
+	RefTable       string   // table name of the referenced type
+	RefColumns     []string // referenced columns
+	OnDeleteAction string
+}
+
+// RowDeletionPolicy is models.RowDeletionPolicy.
+type RowDeletionPolicy struct {
+	Column  string
+	NumDays int64
+}
+
+// ChangeStream is models.ChangeStream.
+type ChangeStream struct {
+	Name       string
+	StreamName string
+	All        bool
+	Tables     []*ChangeStreamTable
+	Options    map[string]string
+}
+
+// ChangeStreamTable is models.ChangeStreamTable.
+type ChangeStreamTable struct {
+	Table      string // table name of the type
+	AllColumns bool
+	Columns    []string
+}
+
+// NewSchema converts the schema for a request.
+func NewSchema(schema *models.Schema) *Schema {
+	s := &Schema{Dialect: schema.Dialect}
+
+	for _, t := range schema.Types {
+		typ := &Type{
+			Name:             t.Name,
+			TableName:        t.TableName,
+			Schema:           t.Schema,
+			PrimaryKeys:      indexKeys(t.PrimaryKeys),
+			Fields:           t.Fields,
+			InterleaveType:   t.InterleaveType,
+			OnDeleteAction:   t.OnDeleteAction,
+			IsView:           t.IsView,
+			CheckConstraints: t.CheckConstraints,
+		}
+		if t.Parent != nil {
+			typ.Parent = t.Parent.TableName
+		}
+		if p := t.RowDeletionPolicy; p != nil {
+			typ.RowDeletionPolicy = &RowDeletionPolicy{Column: p.Field.ColumnName, NumDays: p.NumDays}
+		}
+
+		for _, ix := range t.Indexes {
+			index := &Index{
+				Name:           ix.Name,
+				FuncName:       ix.FuncName,
+				LegacyFuncName: ix.LegacyFuncName,
+				Fields:         columnNames(ix.Fields),
+				StoringFields:  columnNames(ix.StoringFields),
+				NullableFields: columnNames(ix.NullableFields),
+				IndexName:      ix.IndexName,
+				IsUnique:       ix.IsUnique,
+				IsPrimary:      ix.IsPrimary,
+				NullFiltered:   ix.NullFiltered,
+				InterleavedIn:  ix.InterleavedIn,
+				IndexType:      ix.IndexType,
+				Keys:           indexKeys(ix.Keys),
+			}
+			typ.Indexes = append(typ.Indexes, index)
+		}
+
+		for _, fk := range t.ForeignKeys {
+			typ.ForeignKeys = append(typ.ForeignKeys, &ForeignKey{
+				Name:           fk.Name,
+				ConstraintName: fk.ConstraintName,
+				Columns:        columnNames(fk.Fields),
+				RefTable:       fk.RefType.TableName,
+				RefColumns:     columnNames(fk.RefFields),
+				OnDeleteAction: fk.OnDeleteAction,
+			})
+		}
+
+		s.Types = append(s.Types, typ)
+	}
+
+	for _, cs := range schema.ChangeStreams {
+		stream := &ChangeStream{
+			Name:       cs.Name,
+			StreamName: cs.StreamName,
+			All:        cs.All,
+			Options:    cs.Options,
+		}
+		for _, t := range cs.Tables {
+			stream.Tables = append(stream.Tables, &ChangeStreamTable{
+				Table:      t.Type.TableName,
+				AllColumns: t.AllColumns,
+				Columns:    columnNames(t.Fields),
+			})
+		}
+		s.ChangeStreams = append(s.ChangeStreams, stream)
+	}
+
+	return s
+}
+
+// Models converts the schema to the models as the templates get. It returns
+// an error if a table or a column referred in the schema does not exist.
+func (s *Schema) Models() (*models.Schema, error) {
+	schema := &models.Schema{Dialect: s.Dialect}
+
+	typeMap := make(map[string]*models.Type, len(s.Types))
+	for _, t := range s.Types {
+		typeMap[t.TableName] = &models.Type{
+			Name:             t.Name,
+			TableName:        t.TableName,
+			Schema:           t.Schema,
+			Fields:           t.Fields,
+			InterleaveType:   t.InterleaveType,
+			OnDeleteAction:   t.OnDeleteAction,
+			IsView:           t.IsView,
+			CheckConstraints: t.CheckConstraints,
+		}
+	}
+
+	for _, t := range s.Types {
+		typ := typeMap[t.TableName]
+
+		var err error
+		if typ.PrimaryKeys, err = findKeys(typ, t.PrimaryKeys); err != nil {
+			return nil, err
+		}
+		for _, k := range typ.PrimaryKeys {
+			typ.PrimaryKeyFields = append(typ.PrimaryKeyFields, k.Field)
+		}
+
+		if t.Parent != "" {
+			parent, ok := typeMap[t.Parent]
+			if !ok {
+				return nil, fmt.Errorf("unknown parent table %s of the table %s", t.Parent, t.TableName)
+			}
+			typ.Parent = parent
+			parent.Children = append(parent.Children, typ)
+		}
+
+		if p := t.RowDeletionPolicy; p != nil {
+			fields, err := findFields(typ, []string{p.Column})
+			if err != nil {
+				return nil, err
+			}
+			typ.RowDeletionPolicy = &models.RowDeletionPolicy{Field: fields[0], NumDays: p.NumDays}
+		}
+
+		for _, ix := range t.Indexes {
+			index := &models.Index{
+				Name:           ix.Name,
+				FuncName:       ix.FuncName,
+				LegacyFuncName: ix.LegacyFuncName,
+				Type:           typ,
+				IndexName:      ix.IndexName,
+				IsUnique:       ix.IsUnique,
+				IsPrimary:      ix.IsPrimary,
+				NullFiltered:   ix.NullFiltered,
+				InterleavedIn:  ix.InterleavedIn,
+				IndexType:      ix.IndexType,
+			}
+			if index.Fields, err = findFields(typ, ix.Fields); err != nil {
+				return nil, err
+			}
+			if index.StoringFields, err = findFields(typ, ix.StoringFields); err != nil {
+				return nil, err
+			}
+			if index.NullableFields, err = findFields(typ, ix.NullableFields); err != nil {
+				return nil, err
+			}
+			if index.Keys, err = findKeys(typ, ix.Keys); err != nil {
+				return nil, err
+			}
+			typ.Indexes = append(typ.Indexes, index)
+		}
+
+		for _, fk := range t.ForeignKeys {
+			refType, ok := typeMap[fk.RefTable]
+			if !ok {
+				return nil, fmt.Errorf("unknown referenced table %s of the foreign key %s", fk.RefTable, fk.ConstraintName)
+			}
+			foreignKey := &models.ForeignKey{
+				Name:           fk.Name,
+				ConstraintName: fk.ConstraintName,
+				Type:           typ,
+				RefType:        refType,
+				OnDeleteAction: fk.OnDeleteAction,
+			}
+			if foreignKey.Fields, err = findFields(typ, fk.Columns); err != nil {
+				return nil, err
+			}
+			if foreignKey.RefFields, err = findFields(refType, fk.RefColumns); err != nil {
+				return nil, err
+			}
+			typ.ForeignKeys = append(typ.ForeignKeys, foreignKey)
+		}
+
+		schema.Types = append(schema.Types, typ)
+	}
+
+	// sort by table name as the loader does
+	for _, t := range schema.Types {
+		sort.Slice(t.Children, func(i, j int) bool {
+			return t.Children[i].TableName < t.Children[j].TableName
+		})
+	}
+
+	for _, cs := range s.ChangeStreams {
+		stream := &models.ChangeStream{
+			Name:       cs.Name,
+			StreamName: cs.StreamName,
+			All:        cs.All,
+			Options:    cs.Options,
+		}
+		for _, t := range cs.Tables {
+			typ, ok := typeMap[t.Table]
+			if !ok {
+				return nil, fmt.Errorf("unknown table %s of the change stream %s", t.Table, cs.StreamName)
+			}
+			fields, err := findFields(typ, t.Columns)
+			if err != nil {
+				return nil, err
+			}
+			stream.Tables = append(stream.Tables, &models.ChangeStreamTable{Type: typ, AllColumns: t.AllColumns, Fields: fields})
+			typ.ChangeStreams = append(typ.ChangeStreams, stream)
+		}
+		schema.ChangeStreams = append(schema.ChangeStreams, stream)
+	}
+
+	return schema, nil
+}
+
+// columnNames returns the column names of the fields.
+func columnNames(fields []*models.Field) []string {
+	if fields == nil {
+		return nil
+	}
+
+	names := make([]string, 0, len(fields))
+	for _, f := range fields {
+		names = append(names, f.ColumnName)
+	}
+	return names
+}
+
+// indexKeys returns the keys referring the fields by the column names.
+func indexKeys(keys []*models.IndexKey) []*IndexKey {
+	if keys == nil {
+		return nil
+	}
+
+	ret := make([]*IndexKey, 0, len(keys))
+	for _, k := range keys {
+		ret = append(ret, &IndexKey{Column: k.Field.ColumnName, Desc: k.Desc})
+	}
+	return ret
+}
+
+// findKeys returns the keys of the type by the column names of keys.
+func findKeys(typ *models.Type, keys []*IndexKey) ([]*models.IndexKey, error) {
+	if keys == nil {
+		return nil, nil
+	}
+
+	ret := make([]*models.IndexKey, 0, len(keys))
+	for _, k := range keys {
+		fields, err := findFields(typ, []string{k.Column})
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, &models.IndexKey{Field: fields[0], Desc: k.Desc})
+	}
+	return ret, nil
+}
+
+// findFields returns the fields of the type by the column names.
+func findFields(typ *models.Type, columns []string) ([]*models.Field, error) {
+	if columns == nil {
+		return nil, nil
+	}
+
+	fields := make([]*models.Field, 0, len(columns))
+	for _, c := range columns {
+		var field *models.Field
+		for _, f := range typ.Fields {
+			if f.ColumnName == c {
+				field = f
+				break
+			}
+		}
+		if field == nil {
+			return nil, fmt.Errorf("unknown column %s in the table %s", c, typ.TableName)
+		}
+		fields = append(fields, field)
+	}
+	return fields, nil
+}
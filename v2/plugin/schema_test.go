@@ -0,0 +1,114 @@
+// Copyright (c) 2020 Mercari, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package plugin
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"go.mercari.io/yo/v2/config"
+	"go.mercari.io/yo/v2/internal"
+	"go.mercari.io/yo/v2/loader"
+	"go.mercari.io/yo/v2/models"
+)
+
+func TestSchema_Models(t *testing.T) {
+	cfg, err := config.Load("../test/testdata/config.yml")
+	if err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+	source, err := loader.NewSchemaParserSource("../test/testdata/schema.sql")
+	if err != nil {
+		t.Fatalf("failed to create schema parser source: %v", err)
+	}
+	inflector, err := internal.NewInflector(nil)
+	if err != nil {
+		t.Fatalf("failed to create inflector: %v", err)
+	}
+	expected, err := loader.NewTypeLoader(source, inflector, loader.Option{Config: cfg}).LoadSchema()
+	if err != nil {
+		t.Fatalf("failed to load schema: %v", err)
+	}
+
+	// encode and decode the schema as a request does
+	b, err := json.Marshal(NewSchema(expected))
+	if err != nil {
+		t.Fatalf("failed to encode schema: %v", err)
+	}
+	var s Schema
+	if err := json.Unmarshal(b, &s); err != nil {
+		t.Fatalf("failed to decode schema: %v", err)
+	}
+
+	got, err := s.Models()
+	if err != nil {
+		t.Fatalf("failed to convert schema: %v", err)
+	}
+	if diff := cmp.Diff(got, expected); diff != "" {
+		t.Errorf("(-got, +want)\n%s", diff)
+	}
+}
+
+func TestSchema_Models_Errors(t *testing.T) {
+	field := &models.Field{Name: "ID", ColumnName: "Id"}
+
+	table := map[string]struct {
+		schema   *Schema
+		expected string
+	}{
+		"UnknownColumn": {
+			schema: &Schema{
+				Types: []*Type{{TableName: "Singers", PrimaryKeys: []*IndexKey{{Column: "SingerId"}}, Fields: []*models.Field{field}}},
+			},
+			expected: "unknown column SingerId in the table Singers",
+		},
+		"UnknownParent": {
+			schema: &Schema{
+				Types: []*Type{{TableName: "Albums", Parent: "Singers"}},
+			},
+			expected: "unknown parent table Singers of the table Albums",
+		},
+		"UnknownRefTable": {
+			schema: &Schema{
+				Types: []*Type{{TableName: "Albums", ForeignKeys: []*ForeignKey{{ConstraintName: "FK_Singers", RefTable: "Singers"}}}},
+			},
+			expected: "unknown referenced table Singers of the foreign key FK_Singers",
+		},
+		"UnknownChangeStreamTable": {
+			schema: &Schema{
+				ChangeStreams: []*ChangeStream{{StreamName: "SingerStream", Tables: []*ChangeStreamTable{{Table: "Singers"}}}},
+			},
+			expected: "unknown table Singers of the change stream SingerStream",
+		},
+	}
+
+	for name, tc := range table {
+		t.Run(name, func(t *testing.T) {
+			_, err := tc.schema.Models()
+			if err == nil {
+				t.Fatal("expected an error, but got nil")
+			}
+			if err.Error() != tc.expected {
+				t.Errorf("expected error %q, but got %q", tc.expected, err.Error())
+			}
+		})
+	}
+}
@@ -25,6 +25,7 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"sort"
 	"testing"
 	"time"
 
@@ -1115,6 +1116,361 @@ func TestGeneratedColumn(t *testing.T) {
 	})
 }
 
+func TestInterleavedChildItem(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := testutil.DeleteAllData(ctx, client); err != nil {
+		t.Fatalf("failed to clear data: %v", err)
+	}
+
+	muts := []*spanner.Mutation{
+		(&default_models.ParentItem{ParentID: 1, Name: "p1"}).Insert(ctx),
+		(&default_models.ParentItem{ParentID: 2, Name: "p2"}).Insert(ctx),
+		(&default_models.ChildItem{ParentID: 1, ChildID: 1, Name: "c11"}).Insert(ctx),
+		(&default_models.ChildItem{ParentID: 1, ChildID: 2, Name: "c12"}).Insert(ctx),
+		(&default_models.ChildItem{ParentID: 2, ChildID: 1, Name: "c21"}).Insert(ctx),
+	}
+	if _, err := client.Apply(ctx, muts); err != nil {
+		t.Fatalf("Apply failed: %v", err)
+	}
+
+	t.Run("ReadByParentKey", func(t *testing.T) {
+		got, err := default_models.ReadChildItemByParentItemKey(ctx, client.Single(), 1)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		want := []*default_models.ChildItem{
+			{ParentID: 1, ChildID: 1, Name: "c11"},
+			{ParentID: 1, ChildID: 2, Name: "c12"},
+		}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("(-got, +want)\n%s", diff)
+		}
+	})
+
+	t.Run("DeleteByParentKey", func(t *testing.T) {
+		if _, err := client.Apply(ctx, []*spanner.Mutation{default_models.DeleteChildItemByParentItemKey(ctx, 1)}); err != nil {
+			t.Fatalf("Apply failed: %v", err)
+		}
+
+		got, err := default_models.ReadChildItemByParentItemKey(ctx, client.Single(), 1)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(got) != 0 {
+			t.Errorf("expected no rows, but got %d", len(got))
+		}
+
+		got, err = default_models.ReadChildItemByParentItemKey(ctx, client.Single(), 2)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(got) != 1 {
+			t.Errorf("expected 1 row, but got %d", len(got))
+		}
+	})
+}
+
+func TestForeignKey(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := testutil.DeleteAllData(ctx, client); err != nil {
+		t.Fatalf("failed to clear data: %v", err)
+	}
+
+	item := &default_models.Item{ID: 1, Price: 100}
+	muts := []*spanner.Mutation{
+		item.Insert(ctx),
+		(&default_models.Item{ID: 2, Price: 200}).Insert(ctx),
+		(&default_models.FereignItem{ID: 10, ItemID: 1, Category: 1}).Insert(ctx),
+		(&default_models.FereignItem{ID: 11, ItemID: 1, Category: 2}).Insert(ctx),
+		(&default_models.FereignItem{ID: 12, ItemID: 2, Category: 3}).Insert(ctx),
+	}
+	if _, err := client.Apply(ctx, muts); err != nil {
+		t.Fatalf("Apply failed: %v", err)
+	}
+
+	t.Run("FindReferenced", func(t *testing.T) {
+		fi := &default_models.FereignItem{ID: 10, ItemID: 1, Category: 1}
+		got, err := fi.FindItem(ctx, client.Single())
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if diff := cmp.Diff(item, got); diff != "" {
+			t.Errorf("(-got, +want)\n%s", diff)
+		}
+	})
+
+	t.Run("FindReferencedNotFound", func(t *testing.T) {
+		fi := &default_models.FereignItem{ID: 10, ItemID: 100, Category: 1}
+		_, err := fi.FindItem(ctx, client.Single())
+		testNotFound(t, err, true)
+	})
+
+	t.Run("FindReferencing", func(t *testing.T) {
+		got, err := default_models.FindFereignItemsByItem(ctx, client.Single(), item)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		sort.Slice(got, func(i, j int) bool {
+			return got[i].ID < got[j].ID
+		})
+
+		want := []*default_models.FereignItem{
+			{ID: 10, ItemID: 1, Category: 1},
+			{ID: 11, ItemID: 1, Category: 2},
+		}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("(-got, +want)\n%s", diff)
+		}
+	})
+}
+
+func TestView(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := testutil.DeleteAllData(ctx, client); err != nil {
+		t.Fatalf("failed to clear data: %v", err)
+	}
+
+	muts := []*spanner.Mutation{
+		(&default_models.Item{ID: 1, Price: 100}).Insert(ctx),
+		(&default_models.FereignItem{ID: 10, ItemID: 1, Category: 1}).Insert(ctx),
+		(&default_models.FereignItem{ID: 11, ItemID: 1, Category: 2}).Insert(ctx),
+	}
+	if _, err := client.Apply(ctx, muts); err != nil {
+		t.Fatalf("Apply failed: %v", err)
+	}
+
+	got, err := default_models.QueryFereignItemPrice(ctx, client.Single(), spanner.Statement{
+		SQL:    "Category = @category",
+		Params: map[string]interface{}{"category": 2},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []*default_models.FereignItemPrice{
+		{
+			ID:        spanner.NullInt64{Int64: 11, Valid: true},
+			Category:  spanner.NullInt64{Int64: 2, Valid: true},
+			ItemPrice: spanner.NullInt64{Int64: 100, Valid: true},
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("(-got, +want)\n%s", diff)
+	}
+}
+
+func TestInsertWithDefaults(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := testutil.DeleteAllData(ctx, client); err != nil {
+		t.Fatalf("failed to clear data: %v", err)
+	}
+
+	dv := &default_models.DefaultValue{
+		ID:       1,
+		Name:     "ignored",
+		Quantity: 100,
+		Note:     spanner.NullString{StringVal: "note", Valid: true},
+	}
+	if _, err := client.Apply(ctx, []*spanner.Mutation{dv.InsertWithDefaults(ctx)}); err != nil {
+		t.Fatalf("Apply failed: %v", err)
+	}
+
+	got, err := default_models.FindDefaultValue(ctx, client.Single(), 1)
+	if err != nil {
+		t.Fatalf("FindDefaultValue failed: %v", err)
+	}
+
+	want := &default_models.DefaultValue{
+		ID:       1,
+		Name:     "unknown",
+		Quantity: 10,
+		Note:     spanner.NullString{StringVal: "note", Valid: true},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("(-got, +want)\n%s", diff)
+	}
+}
+
+func TestCommitTimestamp(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := testutil.DeleteAllData(ctx, client); err != nil {
+		t.Fatalf("failed to clear data: %v", err)
+	}
+
+	createdAt := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	ct := &default_models.CommitTimestamp{
+		ID:        1,
+		Name:      "name",
+		CreatedAt: createdAt,
+	}
+	commitTs, err := client.Apply(ctx, []*spanner.Mutation{ct.Insert(ctx)})
+	if err != nil {
+		t.Fatalf("Apply failed: %v", err)
+	}
+
+	got, err := default_models.FindCommitTimestamp(ctx, client.Single(), 1)
+	if err != nil {
+		t.Fatalf("FindCommitTimestamp failed: %v", err)
+	}
+
+	want := &default_models.CommitTimestamp{
+		ID:        1,
+		Name:      "name",
+		CreatedAt: createdAt,
+		UpdatedAt: commitTs,
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("(-got, +want)\n%s", diff)
+	}
+}
+
+func TestFloat32(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := testutil.DeleteAllData(ctx, client); err != nil {
+		t.Fatalf("failed to clear data: %v", err)
+	}
+
+	item := &default_models.ScoredItem{
+		ID:        1,
+		Score:     1.5,
+		ScoreNull: spanner.NullFloat32{Float32: 2.5, Valid: true},
+		Scores:    []float32{0.5, 1.5},
+	}
+	if _, err := client.Apply(ctx, []*spanner.Mutation{item.Insert(ctx)}); err != nil {
+		t.Fatalf("Apply failed: %v", err)
+	}
+
+	got, err := default_models.FindScoredItem(ctx, client.Single(), 1)
+	if err != nil {
+		t.Fatalf("FindScoredItem failed: %v", err)
+	}
+
+	if diff := cmp.Diff(item, got); diff != "" {
+		t.Errorf("(-got, +want)\n%s", diff)
+	}
+}
+
+func TestRowDeletionPolicy(t *testing.T) {
+	want := default_models.YORowDeletionPolicy{Column: "ExpiredAt", NumDays: 30}
+	if diff := cmp.Diff(want, default_models.ExpiringItemRowDeletionPolicy()); diff != "" {
+		t.Errorf("(-got, +want)\n%s", diff)
+	}
+
+	expiredAt := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	table := []struct {
+		name      string
+		expiredAt spanner.NullTime
+		now       time.Time
+		expected  bool
+	}{
+		{name: "Null", expiredAt: spanner.NullTime{}, now: expiredAt.AddDate(1, 0, 0), expected: false},
+		{name: "BeforeInterval", expiredAt: spanner.NullTime{Time: expiredAt, Valid: true}, now: expiredAt.Add(30 * 24 * time.Hour), expected: false},
+		{name: "AfterInterval", expiredAt: spanner.NullTime{Time: expiredAt, Valid: true}, now: expiredAt.Add(30*24*time.Hour + time.Second), expected: true},
+	}
+
+	for _, tc := range table {
+		t.Run(tc.name, func(t *testing.T) {
+			item := &default_models.ExpiringItem{ID: 1, Name: "name", ExpiredAt: tc.expiredAt}
+			if got := item.IsExpired(tc.now); got != tc.expected {
+				t.Errorf("expect %v, but got %v", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := testutil.DeleteAllData(ctx, client); err != nil {
+		t.Fatalf("failed to clear data: %v", err)
+	}
+
+	valid := func() *default_models.CheckedItem {
+		return &default_models.CheckedItem{
+			ID:       1,
+			Name:     "name",
+			Price:    100,
+			Discount: spanner.NullInt64{Int64: 10, Valid: true},
+			Status:   "ACTIVE",
+			Code:     spanner.NullString{StringVal: "ABC", Valid: true},
+		}
+	}
+
+	t.Run("Valid", func(t *testing.T) {
+		ci := valid()
+		if err := ci.Validate(); err != nil {
+			t.Fatalf("Validate failed: %v", err)
+		}
+		if _, err := client.Apply(ctx, []*spanner.Mutation{ci.Insert(ctx)}); err != nil {
+			t.Fatalf("Apply failed: %v", err)
+		}
+	})
+
+	t.Run("NullValues", func(t *testing.T) {
+		ci := valid()
+		ci.Discount = spanner.NullInt64{}
+		ci.Code = spanner.NullString{}
+		if err := ci.Validate(); err != nil {
+			t.Fatalf("Validate failed: %v", err)
+		}
+	})
+
+	table := []struct {
+		name   string
+		modify func(ci *default_models.CheckedItem)
+	}{
+		{
+			name:   "Length",
+			modify: func(ci *default_models.CheckedItem) { ci.Name = "too long name" },
+		},
+		{
+			name:   "Comparison",
+			modify: func(ci *default_models.CheckedItem) { ci.Price = 0 },
+		},
+		{
+			name:   "Between",
+			modify: func(ci *default_models.CheckedItem) { ci.Discount = spanner.NullInt64{Int64: 101, Valid: true} },
+		},
+		{
+			name:   "In",
+			modify: func(ci *default_models.CheckedItem) { ci.Status = "UNKNOWN" },
+		},
+		{
+			name:   "Regexp",
+			modify: func(ci *default_models.CheckedItem) { ci.Code = spanner.NullString{StringVal: "abc", Valid: true} },
+		},
+	}
+
+	for _, tc := range table {
+		t.Run(tc.name, func(t *testing.T) {
+			ci := valid()
+			tc.modify(ci)
+
+			err := ci.Validate()
+			if err == nil {
+				t.Fatalf("Validate must fail")
+			}
+			testGRPCStatus(t, err, codes.InvalidArgument)
+			testTableName(t, err, "CheckedItems")
+		})
+	}
+}
+
 func TestSessionNotFound(t *testing.T) {
 	dbName := testutil.DatabaseName(spannerProjectName, spannerInstanceName, spannerDatabaseName)
 
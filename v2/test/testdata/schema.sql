@@ -171,3 +171,64 @@ CREATE TABLE Inflectionzz (
   X STRING(32) NOT NULL,
   Y STRING(32) NOT NULL,
 ) PRIMARY KEY(X);
+
+CREATE TABLE ParentItems (
+  ParentID INT64 NOT NULL,
+  Name STRING(32) NOT NULL,
+) PRIMARY KEY(ParentID);
+
+CREATE TABLE ChildItems (
+  ParentID INT64 NOT NULL,
+  ChildID INT64 NOT NULL,
+  Name STRING(32) NOT NULL,
+) PRIMARY KEY(ParentID, ChildID),
+  INTERLEAVE IN PARENT ParentItems ON DELETE CASCADE;
+
+CREATE VIEW FereignItemPrices SQL SECURITY INVOKER AS
+SELECT fi.ID, fi.Category, i.Price AS ItemPrice
+FROM FereignItems AS fi JOIN Items AS i ON fi.ItemID = i.ID;
+
+CREATE CHANGE STREAM ItemStream
+  FOR Items, FereignItems(Category)
+  OPTIONS (value_capture_type = 'NEW_ROW');
+
+CREATE TABLE DefaultValues (
+  ID INT64 NOT NULL,
+  Name STRING(32) NOT NULL DEFAULT ("unknown"),
+  Quantity INT64 NOT NULL DEFAULT (10),
+  Note STRING(32),
+) PRIMARY KEY(ID);
+
+CREATE TABLE CommitTimestamps (
+  ID INT64 NOT NULL,
+  Name STRING(32) NOT NULL,
+  CreatedAt TIMESTAMP NOT NULL OPTIONS (allow_commit_timestamp = true),
+  UpdatedAt TIMESTAMP NOT NULL OPTIONS (allow_commit_timestamp = true),
+) PRIMARY KEY(ID);
+
+CREATE TABLE CheckedItems (
+  ID INT64 NOT NULL,
+  Name STRING(8) NOT NULL,
+  Price INT64 NOT NULL,
+  Discount INT64,
+  Status STRING(16) NOT NULL,
+  Code STRING(MAX),
+  CONSTRAINT CK_Price CHECK (Price > 0),
+  CONSTRAINT CK_Discount CHECK (Discount BETWEEN 0 AND Price),
+  CONSTRAINT CK_Status CHECK (Status IN ('ACTIVE', 'INACTIVE')),
+  CONSTRAINT CK_Code CHECK (REGEXP_CONTAINS(Code, r'^[A-Z]{3}$')),
+) PRIMARY KEY(ID);
+
+CREATE TABLE ExpiringItems (
+  ID INT64 NOT NULL,
+  Name STRING(32) NOT NULL,
+  ExpiredAt TIMESTAMP,
+) PRIMARY KEY(ID),
+  ROW DELETION POLICY (OLDER_THAN(ExpiredAt, INTERVAL 30 DAY));
+
+CREATE TABLE ScoredItems (
+  ID INT64 NOT NULL,
+  Score FLOAT32 NOT NULL,
+  ScoreNull FLOAT32,
+  Scores ARRAY<FLOAT32>,
+) PRIMARY KEY(ID);
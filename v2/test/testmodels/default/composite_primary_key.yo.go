@@ -6,6 +6,7 @@ package models
 import (
 	"context"
 	"fmt"
+	"unicode/utf8"
 
 	"cloud.google.com/go/spanner"
 	"google.golang.org/api/iterator"
@@ -123,6 +124,45 @@ func newCompositePrimaryKey_Decoder(cols []string) func(*spanner.Row) (*Composit
 	}
 }
 
+// Validate checks the values of CompositePrimaryKey against the NOT NULL, the length
+// and the CHECK constraints of 'CompositePrimaryKeys' before writing them. CHECK
+// constraints that cannot be translated into Go are left to Spanner.
+func (cpk *CompositePrimaryKey) Validate() error {
+	return cpk.validateColumns(CompositePrimaryKeyWritableColumns())
+}
+
+// validateColumns checks the values of the columns in cols in the same way as
+// Validate. The constraints on the other columns, such as the columns left to
+// their DEFAULT values, are not checked.
+func (cpk *CompositePrimaryKey) validateColumns(cols []string) error {
+	written := make(map[string]bool, len(cols))
+	for _, col := range cols {
+		written[col] = true
+	}
+
+	if written["PKey1"] {
+		if utf8.RuneCountInString(cpk.PKey1) > 32 {
+			return newErrorWithCode(codes.InvalidArgument, "CompositePrimaryKey.Validate", "CompositePrimaryKeys", fmt.Errorf("PKey1 must be at most 32 characters"))
+		}
+	}
+	if written["X"] {
+		if utf8.RuneCountInString(cpk.X) > 32 {
+			return newErrorWithCode(codes.InvalidArgument, "CompositePrimaryKey.Validate", "CompositePrimaryKeys", fmt.Errorf("X must be at most 32 characters"))
+		}
+	}
+	if written["Y"] {
+		if utf8.RuneCountInString(cpk.Y) > 32 {
+			return newErrorWithCode(codes.InvalidArgument, "CompositePrimaryKey.Validate", "CompositePrimaryKeys", fmt.Errorf("Y must be at most 32 characters"))
+		}
+	}
+	if written["Z"] {
+		if utf8.RuneCountInString(cpk.Z) > 32 {
+			return newErrorWithCode(codes.InvalidArgument, "CompositePrimaryKey.Validate", "CompositePrimaryKeys", fmt.Errorf("Z must be at most 32 characters"))
+		}
+	}
+	return nil
+}
+
 // Insert returns a Mutation to insert a row into a table. If the row already
 // exists, the write or transaction fails.
 func (cpk *CompositePrimaryKey) Insert(ctx context.Context) *spanner.Mutation {
@@ -219,7 +259,8 @@ func FindCompositePrimaryKeysByCompositePrimaryKeysByError(ctx context.Context,
 	const sqlstr = "SELECT " +
 		"Id, PKey1, PKey2, Error, X, Y, Z " +
 		"FROM CompositePrimaryKeys@{FORCE_INDEX=CompositePrimaryKeysByError} " +
-		"WHERE Error = @param0"
+		"WHERE Error = @param0" +
+		" ORDER BY PKey1, PKey2"
 
 	stmt := spanner.NewStatement(sqlstr)
 	stmt.Params["param0"] = yoEncode(e)
@@ -294,7 +335,8 @@ func FindCompositePrimaryKeysByCompositePrimaryKeysByError2(ctx context.Context,
 	const sqlstr = "SELECT " +
 		"Id, PKey1, PKey2, Error, X, Y, Z " +
 		"FROM CompositePrimaryKeys@{FORCE_INDEX=CompositePrimaryKeysByError2} " +
-		"WHERE Error = @param0"
+		"WHERE Error = @param0" +
+		" ORDER BY PKey1, PKey2"
 
 	stmt := spanner.NewStatement(sqlstr)
 	stmt.Params["param0"] = yoEncode(e)
@@ -370,7 +412,8 @@ func FindCompositePrimaryKeysByCompositePrimaryKeysByError3(ctx context.Context,
 	const sqlstr = "SELECT " +
 		"Id, PKey1, PKey2, Error, X, Y, Z " +
 		"FROM CompositePrimaryKeys@{FORCE_INDEX=CompositePrimaryKeysByError3} " +
-		"WHERE Error = @param0"
+		"WHERE Error = @param0" +
+		" ORDER BY PKey1, PKey2"
 
 	stmt := spanner.NewStatement(sqlstr)
 	stmt.Params["param0"] = yoEncode(e)
@@ -447,7 +490,8 @@ func FindCompositePrimaryKeysByCompositePrimaryKeysByXY(ctx context.Context, db
 	const sqlstr = "SELECT " +
 		"Id, PKey1, PKey2, Error, X, Y, Z " +
 		"FROM CompositePrimaryKeys@{FORCE_INDEX=CompositePrimaryKeysByXY} " +
-		"WHERE X = @param0 AND Y = @param1"
+		"WHERE X = @param0 AND Y = @param1" +
+		" ORDER BY PKey1, PKey2"
 
 	stmt := spanner.NewStatement(sqlstr)
 	stmt.Params["param0"] = yoEncode(x)
@@ -6,6 +6,7 @@ package models
 import (
 	"context"
 	"fmt"
+	"unicode/utf8"
 
 	"cloud.google.com/go/spanner"
 	"google.golang.org/api/iterator"
@@ -123,6 +124,45 @@ func newCustomCompositePrimaryKey_Decoder(cols []string) func(*spanner.Row) (*Cu
 	}
 }
 
+// Validate checks the values of CustomCompositePrimaryKey against the NOT NULL, the length
+// and the CHECK constraints of 'CustomCompositePrimaryKeys' before writing them. CHECK
+// constraints that cannot be translated into Go are left to Spanner.
+func (ccpk *CustomCompositePrimaryKey) Validate() error {
+	return ccpk.validateColumns(CustomCompositePrimaryKeyWritableColumns())
+}
+
+// validateColumns checks the values of the columns in cols in the same way as
+// Validate. The constraints on the other columns, such as the columns left to
+// their DEFAULT values, are not checked.
+func (ccpk *CustomCompositePrimaryKey) validateColumns(cols []string) error {
+	written := make(map[string]bool, len(cols))
+	for _, col := range cols {
+		written[col] = true
+	}
+
+	if written["PKey1"] {
+		if utf8.RuneCountInString(ccpk.PKey1) > 32 {
+			return newErrorWithCode(codes.InvalidArgument, "CustomCompositePrimaryKey.Validate", "CustomCompositePrimaryKeys", fmt.Errorf("PKey1 must be at most 32 characters"))
+		}
+	}
+	if written["X"] {
+		if utf8.RuneCountInString(ccpk.X) > 32 {
+			return newErrorWithCode(codes.InvalidArgument, "CustomCompositePrimaryKey.Validate", "CustomCompositePrimaryKeys", fmt.Errorf("X must be at most 32 characters"))
+		}
+	}
+	if written["Y"] {
+		if utf8.RuneCountInString(ccpk.Y) > 32 {
+			return newErrorWithCode(codes.InvalidArgument, "CustomCompositePrimaryKey.Validate", "CustomCompositePrimaryKeys", fmt.Errorf("Y must be at most 32 characters"))
+		}
+	}
+	if written["Z"] {
+		if utf8.RuneCountInString(ccpk.Z) > 32 {
+			return newErrorWithCode(codes.InvalidArgument, "CustomCompositePrimaryKey.Validate", "CustomCompositePrimaryKeys", fmt.Errorf("Z must be at most 32 characters"))
+		}
+	}
+	return nil
+}
+
 // Insert returns a Mutation to insert a row into a table. If the row already
 // exists, the write or transaction fails.
 func (ccpk *CustomCompositePrimaryKey) Insert(ctx context.Context) *spanner.Mutation {
@@ -219,7 +259,8 @@ func FindCustomCompositePrimaryKeysByCustomCompositePrimaryKeysByError(ctx conte
 	const sqlstr = "SELECT " +
 		"Id, PKey1, PKey2, Error, X, Y, Z " +
 		"FROM CustomCompositePrimaryKeys@{FORCE_INDEX=CustomCompositePrimaryKeysByError} " +
-		"WHERE Error = @param0"
+		"WHERE Error = @param0" +
+		" ORDER BY PKey1, PKey2"
 
 	stmt := spanner.NewStatement(sqlstr)
 	stmt.Params["param0"] = yoEncode(e)
@@ -294,7 +335,8 @@ func FindCustomCompositePrimaryKeysByCustomCompositePrimaryKeysByError2(ctx cont
 	const sqlstr = "SELECT " +
 		"Id, PKey1, PKey2, Error, X, Y, Z " +
 		"FROM CustomCompositePrimaryKeys@{FORCE_INDEX=CustomCompositePrimaryKeysByError2} " +
-		"WHERE Error = @param0"
+		"WHERE Error = @param0" +
+		" ORDER BY PKey1, PKey2"
 
 	stmt := spanner.NewStatement(sqlstr)
 	stmt.Params["param0"] = yoEncode(e)
@@ -370,7 +412,8 @@ func FindCustomCompositePrimaryKeysByCustomCompositePrimaryKeysByError3(ctx cont
 	const sqlstr = "SELECT " +
 		"Id, PKey1, PKey2, Error, X, Y, Z " +
 		"FROM CustomCompositePrimaryKeys@{FORCE_INDEX=CustomCompositePrimaryKeysByError3} " +
-		"WHERE Error = @param0"
+		"WHERE Error = @param0" +
+		" ORDER BY PKey1, PKey2"
 
 	stmt := spanner.NewStatement(sqlstr)
 	stmt.Params["param0"] = yoEncode(e)
@@ -447,7 +490,8 @@ func FindCustomCompositePrimaryKeysByCustomCompositePrimaryKeysByXY(ctx context.
 	const sqlstr = "SELECT " +
 		"Id, PKey1, PKey2, Error, X, Y, Z " +
 		"FROM CustomCompositePrimaryKeys@{FORCE_INDEX=CustomCompositePrimaryKeysByXY} " +
-		"WHERE X = @param0 AND Y = @param1"
+		"WHERE X = @param0 AND Y = @param1" +
+		" ORDER BY PKey1, PKey2"
 
 	stmt := spanner.NewStatement(sqlstr)
 	stmt.Params["param0"] = yoEncode(x)
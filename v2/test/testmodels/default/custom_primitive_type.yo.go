@@ -6,6 +6,7 @@ package models
 import (
 	"context"
 	"fmt"
+	"unicode/utf8"
 
 	"cloud.google.com/go/spanner"
 	"google.golang.org/grpc/codes"
@@ -303,6 +304,70 @@ func newCustomPrimitiveType_Decoder(cols []string) func(*spanner.Row) (*CustomPr
 	}
 }
 
+// Validate checks the values of CustomPrimitiveType against the NOT NULL, the length
+// and the CHECK constraints of 'CustomPrimitiveTypes' before writing them. CHECK
+// constraints that cannot be translated into Go are left to Spanner.
+func (cpt *CustomPrimitiveType) Validate() error {
+	return cpt.validateColumns(CustomPrimitiveTypeWritableColumns())
+}
+
+// validateColumns checks the values of the columns in cols in the same way as
+// Validate. The constraints on the other columns, such as the columns left to
+// their DEFAULT values, are not checked.
+func (cpt *CustomPrimitiveType) validateColumns(cols []string) error {
+	written := make(map[string]bool, len(cols))
+	for _, col := range cols {
+		written[col] = true
+	}
+
+	if written["PKey"] {
+		if utf8.RuneCountInString(cpt.PKey) > 32 {
+			return newErrorWithCode(codes.InvalidArgument, "CustomPrimitiveType.Validate", "CustomPrimitiveTypes", fmt.Errorf("PKey must be at most 32 characters"))
+		}
+	}
+	if written["FTArrayInt64"] {
+		if cpt.FTArrayInt64 == nil {
+			return newErrorWithCode(codes.InvalidArgument, "CustomPrimitiveType.Validate", "CustomPrimitiveTypes", fmt.Errorf("FTArrayInt64 must not be NULL"))
+		}
+	}
+	if written["FTArrayInt32"] {
+		if cpt.FTArrayInt32 == nil {
+			return newErrorWithCode(codes.InvalidArgument, "CustomPrimitiveType.Validate", "CustomPrimitiveTypes", fmt.Errorf("FTArrayInt32 must not be NULL"))
+		}
+	}
+	if written["FTArrayInt16"] {
+		if cpt.FTArrayInt16 == nil {
+			return newErrorWithCode(codes.InvalidArgument, "CustomPrimitiveType.Validate", "CustomPrimitiveTypes", fmt.Errorf("FTArrayInt16 must not be NULL"))
+		}
+	}
+	if written["FTArrayInt8"] {
+		if cpt.FTArrayInt8 == nil {
+			return newErrorWithCode(codes.InvalidArgument, "CustomPrimitiveType.Validate", "CustomPrimitiveTypes", fmt.Errorf("FTArrayInt8 must not be NULL"))
+		}
+	}
+	if written["FTArrayUInt64"] {
+		if cpt.FTArrayUINt64 == nil {
+			return newErrorWithCode(codes.InvalidArgument, "CustomPrimitiveType.Validate", "CustomPrimitiveTypes", fmt.Errorf("FTArrayUInt64 must not be NULL"))
+		}
+	}
+	if written["FTArrayUInt32"] {
+		if cpt.FTArrayUINt32 == nil {
+			return newErrorWithCode(codes.InvalidArgument, "CustomPrimitiveType.Validate", "CustomPrimitiveTypes", fmt.Errorf("FTArrayUInt32 must not be NULL"))
+		}
+	}
+	if written["FTArrayUInt16"] {
+		if cpt.FTArrayUINt16 == nil {
+			return newErrorWithCode(codes.InvalidArgument, "CustomPrimitiveType.Validate", "CustomPrimitiveTypes", fmt.Errorf("FTArrayUInt16 must not be NULL"))
+		}
+	}
+	if written["FTArrayUInt8"] {
+		if cpt.FTArrayUINt8 == nil {
+			return newErrorWithCode(codes.InvalidArgument, "CustomPrimitiveType.Validate", "CustomPrimitiveTypes", fmt.Errorf("FTArrayUInt8 must not be NULL"))
+		}
+	}
+	return nil
+}
+
 // Insert returns a Mutation to insert a row into a table. If the row already
 // exists, the write or transaction fails.
 func (cpt *CustomPrimitiveType) Insert(ctx context.Context) *spanner.Mutation {
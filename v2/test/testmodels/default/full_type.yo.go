@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	"cloud.google.com/go/civil"
 	"cloud.google.com/go/spanner"
@@ -307,6 +308,98 @@ func newFullType_Decoder(cols []string) func(*spanner.Row) (*FullType, error) {
 	}
 }
 
+// Validate checks the values of FullType against the NOT NULL, the length
+// and the CHECK constraints of 'FullTypes' before writing them. CHECK
+// constraints that cannot be translated into Go are left to Spanner.
+func (ft *FullType) Validate() error {
+	return ft.validateColumns(FullTypeWritableColumns())
+}
+
+// validateColumns checks the values of the columns in cols in the same way as
+// Validate. The constraints on the other columns, such as the columns left to
+// their DEFAULT values, are not checked.
+func (ft *FullType) validateColumns(cols []string) error {
+	written := make(map[string]bool, len(cols))
+	for _, col := range cols {
+		written[col] = true
+	}
+
+	if written["PKey"] {
+		if utf8.RuneCountInString(ft.PKey) > 32 {
+			return newErrorWithCode(codes.InvalidArgument, "FullType.Validate", "FullTypes", fmt.Errorf("PKey must be at most 32 characters"))
+		}
+	}
+	if written["FTString"] {
+		if utf8.RuneCountInString(ft.FTString) > 32 {
+			return newErrorWithCode(codes.InvalidArgument, "FullType.Validate", "FullTypes", fmt.Errorf("FTString must be at most 32 characters"))
+		}
+	}
+	if written["FTStringNull"] {
+		if ft.FTStringNull.Valid && utf8.RuneCountInString(ft.FTStringNull.StringVal) > 32 {
+			return newErrorWithCode(codes.InvalidArgument, "FullType.Validate", "FullTypes", fmt.Errorf("FTStringNull must be at most 32 characters"))
+		}
+	}
+	if written["FTBytes"] {
+		if ft.FTBytes == nil {
+			return newErrorWithCode(codes.InvalidArgument, "FullType.Validate", "FullTypes", fmt.Errorf("FTBytes must not be NULL"))
+		}
+		if len(ft.FTBytes) > 32 {
+			return newErrorWithCode(codes.InvalidArgument, "FullType.Validate", "FullTypes", fmt.Errorf("FTBytes must be at most 32 bytes"))
+		}
+	}
+	if written["FTBytesNull"] {
+		if len(ft.FTBytesNull) > 32 {
+			return newErrorWithCode(codes.InvalidArgument, "FullType.Validate", "FullTypes", fmt.Errorf("FTBytesNull must be at most 32 bytes"))
+		}
+	}
+	if written["FTJson"] {
+		if !ft.FTJSON.Valid {
+			return newErrorWithCode(codes.InvalidArgument, "FullType.Validate", "FullTypes", fmt.Errorf("FTJson must not be NULL"))
+		}
+	}
+	if written["FTArrayString"] {
+		if ft.FTArrayString == nil {
+			return newErrorWithCode(codes.InvalidArgument, "FullType.Validate", "FullTypes", fmt.Errorf("FTArrayString must not be NULL"))
+		}
+	}
+	if written["FTArrayBool"] {
+		if ft.FTArrayBool == nil {
+			return newErrorWithCode(codes.InvalidArgument, "FullType.Validate", "FullTypes", fmt.Errorf("FTArrayBool must not be NULL"))
+		}
+	}
+	if written["FTArrayBytes"] {
+		if ft.FTArrayBytes == nil {
+			return newErrorWithCode(codes.InvalidArgument, "FullType.Validate", "FullTypes", fmt.Errorf("FTArrayBytes must not be NULL"))
+		}
+	}
+	if written["FTArrayTimestamp"] {
+		if ft.FTArrayTimestamp == nil {
+			return newErrorWithCode(codes.InvalidArgument, "FullType.Validate", "FullTypes", fmt.Errorf("FTArrayTimestamp must not be NULL"))
+		}
+	}
+	if written["FTArrayInt"] {
+		if ft.FTArrayInt == nil {
+			return newErrorWithCode(codes.InvalidArgument, "FullType.Validate", "FullTypes", fmt.Errorf("FTArrayInt must not be NULL"))
+		}
+	}
+	if written["FTArrayFloat"] {
+		if ft.FTArrayFloat == nil {
+			return newErrorWithCode(codes.InvalidArgument, "FullType.Validate", "FullTypes", fmt.Errorf("FTArrayFloat must not be NULL"))
+		}
+	}
+	if written["FTArrayDate"] {
+		if ft.FTArrayDate == nil {
+			return newErrorWithCode(codes.InvalidArgument, "FullType.Validate", "FullTypes", fmt.Errorf("FTArrayDate must not be NULL"))
+		}
+	}
+	if written["FTArrayJson"] {
+		if ft.FTArrayJSON == nil {
+			return newErrorWithCode(codes.InvalidArgument, "FullType.Validate", "FullTypes", fmt.Errorf("FTArrayJson must not be NULL"))
+		}
+	}
+	return nil
+}
+
 // Insert returns a Mutation to insert a row into a table. If the row already
 // exists, the write or transaction fails.
 func (ft *FullType) Insert(ctx context.Context) *spanner.Mutation {
@@ -483,6 +576,7 @@ func FindFullTypesByFullTypesByInTimestampNull(ctx context.Context, db YODB, fTI
 		conds[1] = "FTTimestampNull = @param1"
 	}
 	sqlstr += "WHERE " + strings.Join(conds, " AND ")
+	sqlstr += " ORDER BY PKey"
 
 	stmt := spanner.NewStatement(sqlstr)
 	stmt.Params["param0"] = yoEncode(fTInt)
@@ -558,7 +652,8 @@ func FindFullTypesByFullTypesByIntDate(ctx context.Context, db YODB, fTInt int64
 	const sqlstr = "SELECT " +
 		"PKey, FTString, FTStringNull, FTBool, FTBoolNull, FTBytes, FTBytesNull, FTTimestamp, FTTimestampNull, FTInt, FTIntNull, FTFloat, FTFloatNull, FTDate, FTDateNull, FTJson, FTJsonNull, FTArrayStringNull, FTArrayString, FTArrayBoolNull, FTArrayBool, FTArrayBytesNull, FTArrayBytes, FTArrayTimestampNull, FTArrayTimestamp, FTArrayIntNull, FTArrayInt, FTArrayFloatNull, FTArrayFloat, FTArrayDateNull, FTArrayDate, FTArrayJsonNull, FTArrayJson " +
 		"FROM FullTypes@{FORCE_INDEX=FullTypesByIntDate} " +
-		"WHERE FTInt = @param0 AND FTDate = @param1"
+		"WHERE FTInt = @param0 AND FTDate = @param1" +
+		" ORDER BY PKey"
 
 	stmt := spanner.NewStatement(sqlstr)
 	stmt.Params["param0"] = yoEncode(fTInt)
@@ -634,7 +729,8 @@ func FindFullTypesByFullTypesByIntTimestamp(ctx context.Context, db YODB, fTInt
 	const sqlstr = "SELECT " +
 		"PKey, FTString, FTStringNull, FTBool, FTBoolNull, FTBytes, FTBytesNull, FTTimestamp, FTTimestampNull, FTInt, FTIntNull, FTFloat, FTFloatNull, FTDate, FTDateNull, FTJson, FTJsonNull, FTArrayStringNull, FTArrayString, FTArrayBoolNull, FTArrayBool, FTArrayBytesNull, FTArrayBytes, FTArrayTimestampNull, FTArrayTimestamp, FTArrayIntNull, FTArrayInt, FTArrayFloatNull, FTArrayFloat, FTArrayDateNull, FTArrayDate, FTArrayJsonNull, FTArrayJson " +
 		"FROM FullTypes@{FORCE_INDEX=FullTypesByIntTimestamp} " +
-		"WHERE FTInt = @param0 AND FTTimestamp = @param1"
+		"WHERE FTInt = @param0 AND FTTimestamp = @param1" +
+		" ORDER BY PKey"
 
 	stmt := spanner.NewStatement(sqlstr)
 	stmt.Params["param0"] = yoEncode(fTInt)
@@ -710,7 +806,8 @@ func FindFullTypesByFullTypesByTimestamp(ctx context.Context, db YODB, fTTimesta
 	const sqlstr = "SELECT " +
 		"PKey, FTString, FTStringNull, FTBool, FTBoolNull, FTBytes, FTBytesNull, FTTimestamp, FTTimestampNull, FTInt, FTIntNull, FTFloat, FTFloatNull, FTDate, FTDateNull, FTJson, FTJsonNull, FTArrayStringNull, FTArrayString, FTArrayBoolNull, FTArrayBool, FTArrayBytesNull, FTArrayBytes, FTArrayTimestampNull, FTArrayTimestamp, FTArrayIntNull, FTArrayInt, FTArrayFloatNull, FTArrayFloat, FTArrayDateNull, FTArrayDate, FTArrayJsonNull, FTArrayJson " +
 		"FROM FullTypes@{FORCE_INDEX=FullTypesByTimestamp} " +
-		"WHERE FTTimestamp = @param0"
+		"WHERE FTTimestamp = @param0" +
+		" ORDER BY PKey"
 
 	stmt := spanner.NewStatement(sqlstr)
 	stmt.Params["param0"] = yoEncode(fTTimestamp)
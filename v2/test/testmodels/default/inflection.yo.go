@@ -6,6 +6,7 @@ package models
 import (
 	"context"
 	"fmt"
+	"unicode/utf8"
 
 	"cloud.google.com/go/spanner"
 	"google.golang.org/grpc/codes"
@@ -86,6 +87,35 @@ func newInflection_Decoder(cols []string) func(*spanner.Row) (*Inflection, error
 	}
 }
 
+// Validate checks the values of Inflection against the NOT NULL, the length
+// and the CHECK constraints of 'Inflectionzz' before writing them. CHECK
+// constraints that cannot be translated into Go are left to Spanner.
+func (i *Inflection) Validate() error {
+	return i.validateColumns(InflectionWritableColumns())
+}
+
+// validateColumns checks the values of the columns in cols in the same way as
+// Validate. The constraints on the other columns, such as the columns left to
+// their DEFAULT values, are not checked.
+func (i *Inflection) validateColumns(cols []string) error {
+	written := make(map[string]bool, len(cols))
+	for _, col := range cols {
+		written[col] = true
+	}
+
+	if written["X"] {
+		if utf8.RuneCountInString(i.X) > 32 {
+			return newErrorWithCode(codes.InvalidArgument, "Inflection.Validate", "Inflectionzz", fmt.Errorf("X must be at most 32 characters"))
+		}
+	}
+	if written["Y"] {
+		if utf8.RuneCountInString(i.Y) > 32 {
+			return newErrorWithCode(codes.InvalidArgument, "Inflection.Validate", "Inflectionzz", fmt.Errorf("Y must be at most 32 characters"))
+		}
+	}
+	return nil
+}
+
 // Insert returns a Mutation to insert a row into a table. If the row already
 // exists, the write or transaction fails.
 func (i *Inflection) Insert(ctx context.Context) *spanner.Mutation {
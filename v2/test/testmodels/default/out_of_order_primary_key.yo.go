@@ -6,8 +6,10 @@ package models
 import (
 	"context"
 	"fmt"
+	"unicode/utf8"
 
 	"cloud.google.com/go/spanner"
+	"google.golang.org/grpc/codes"
 )
 
 // OutOfOrderPrimaryKey represents a row from 'OutOfOrderPrimaryKeys'.
@@ -94,6 +96,40 @@ func newOutOfOrderPrimaryKey_Decoder(cols []string) func(*spanner.Row) (*OutOfOr
 	}
 }
 
+// Validate checks the values of OutOfOrderPrimaryKey against the NOT NULL, the length
+// and the CHECK constraints of 'OutOfOrderPrimaryKeys' before writing them. CHECK
+// constraints that cannot be translated into Go are left to Spanner.
+func (ooopk *OutOfOrderPrimaryKey) Validate() error {
+	return ooopk.validateColumns(OutOfOrderPrimaryKeyWritableColumns())
+}
+
+// validateColumns checks the values of the columns in cols in the same way as
+// Validate. The constraints on the other columns, such as the columns left to
+// their DEFAULT values, are not checked.
+func (ooopk *OutOfOrderPrimaryKey) validateColumns(cols []string) error {
+	written := make(map[string]bool, len(cols))
+	for _, col := range cols {
+		written[col] = true
+	}
+
+	if written["PKey1"] {
+		if utf8.RuneCountInString(ooopk.PKey1) > 32 {
+			return newErrorWithCode(codes.InvalidArgument, "OutOfOrderPrimaryKey.Validate", "OutOfOrderPrimaryKeys", fmt.Errorf("PKey1 must be at most 32 characters"))
+		}
+	}
+	if written["PKey2"] {
+		if utf8.RuneCountInString(ooopk.PKey2) > 32 {
+			return newErrorWithCode(codes.InvalidArgument, "OutOfOrderPrimaryKey.Validate", "OutOfOrderPrimaryKeys", fmt.Errorf("PKey2 must be at most 32 characters"))
+		}
+	}
+	if written["PKey3"] {
+		if utf8.RuneCountInString(ooopk.PKey3) > 32 {
+			return newErrorWithCode(codes.InvalidArgument, "OutOfOrderPrimaryKey.Validate", "OutOfOrderPrimaryKeys", fmt.Errorf("PKey3 must be at most 32 characters"))
+		}
+	}
+	return nil
+}
+
 // Insert returns a Mutation to insert a row into a table. If the row already
 // exists, the write or transaction fails.
 func (ooopk *OutOfOrderPrimaryKey) Insert(ctx context.Context) *spanner.Mutation {
@@ -7,7 +7,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"regexp"
 	"strconv"
+	"sync"
 
 	"cloud.google.com/go/spanner"
 	"github.com/googleapis/gax-go/v2/apierror"
@@ -75,6 +77,20 @@ func (e yoError) Timeout() bool   { return e.code == codes.DeadlineExceeded }
 func (e yoError) Temporary() bool { return e.code == codes.DeadlineExceeded }
 func (e yoError) NotFound() bool  { return e.code == codes.NotFound }
 
+var yoRegexps sync.Map
+
+// yoRegexp returns the compiled regular expression of pattern. It is used to
+// validate REGEXP_CONTAINS in CHECK constraints, and the result is cached.
+func yoRegexp(pattern string) *regexp.Regexp {
+	if re, ok := yoRegexps.Load(pattern); ok {
+		return re.(*regexp.Regexp)
+	}
+
+	re := regexp.MustCompile(pattern)
+	yoRegexps.Store(pattern, re)
+	return re
+}
+
 // yoEncode encodes primitive types that spanner library does not support into spanner types before
 // passing to spanner functions. Suppotted primitive types and user defined types that implement
 // spanner.Encoder interface are handled in encoding phase inside spanner libirary.
@@ -148,3 +164,10 @@ func (y *yoPrimitiveDecoder) DecodeSpanner(val interface{}) error {
 
 	return nil
 }
+
+// YORowDeletionPolicy is a row deletion policy (TTL) of a table. Spanner
+// deletes a row in the background after its Column is older than NumDays days.
+type YORowDeletionPolicy struct {
+	Column  string
+	NumDays int64
+}
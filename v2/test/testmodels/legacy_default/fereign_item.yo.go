@@ -8,6 +8,7 @@ import (
 	"fmt"
 
 	"cloud.google.com/go/spanner"
+	"google.golang.org/api/iterator"
 	"google.golang.org/grpc/codes"
 )
 
@@ -93,6 +94,20 @@ func newFereignItem_Decoder(cols []string) func(*spanner.Row) (*FereignItem, err
 	}
 }
 
+// Validate checks the values of FereignItem against the NOT NULL, the length
+// and the CHECK constraints of 'FereignItems' before writing them. CHECK
+// constraints that cannot be translated into Go are left to Spanner.
+func (fi *FereignItem) Validate() error {
+	return fi.validateColumns(FereignItemWritableColumns())
+}
+
+// validateColumns checks the values of the columns in cols in the same way as
+// Validate. The constraints on the other columns, such as the columns left to
+// their DEFAULT values, are not checked.
+func (fi *FereignItem) validateColumns(cols []string) error {
+	return nil
+}
+
 // Insert returns a Mutation to insert a row into a table. If the row already
 // exists, the write or transaction fails.
 func (fi *FereignItem) Insert(ctx context.Context) *spanner.Mutation {
@@ -181,3 +196,83 @@ func (fi *FereignItem) Delete(ctx context.Context) *spanner.Mutation {
 	values, _ := fi.columnsToValues(FereignItemPrimaryKeys())
 	return spanner.Delete("FereignItems", spanner.Key(values))
 }
+
+// FindItem retrieves the Item referenced by the FereignItem.
+//
+// If no row is present, then FindItem returns an error where
+// spanner.ErrCode(err) is codes.NotFound.
+//
+// Generated from foreign key 'FK_ItemID_ForeignItems'.
+func (fi *FereignItem) FindItem(ctx context.Context, db YODB) (*Item, error) {
+	const sqlstr = "SELECT " +
+		"ID, Price " +
+		"FROM Items " +
+		"WHERE ID = @param0 LIMIT 1"
+
+	stmt := spanner.NewStatement(sqlstr)
+	stmt.Params["param0"] = yoEncode(fi.ItemID)
+
+	decoder := newItem_Decoder(ItemColumns())
+
+	// run query
+	YOLog(ctx, sqlstr, fi.ItemID)
+	iter := db.Query(ctx, stmt)
+	defer iter.Stop()
+
+	row, err := iter.Next()
+	if err != nil {
+		if err == iterator.Done {
+			return nil, newErrorWithCode(codes.NotFound, "FereignItem.FindItem", "Items", err)
+		}
+		return nil, newError("FereignItem.FindItem", "Items", err)
+	}
+
+	i, err := decoder(row)
+	if err != nil {
+		return nil, newErrorWithCode(codes.Internal, "FereignItem.FindItem", "Items", err)
+	}
+
+	return i, nil
+}
+
+// FindFereignItemsByItem retrieves multiple rows from 'FereignItems'
+// referencing the Item as a slice of FereignItem.
+//
+// Generated from foreign key 'FK_ItemID_ForeignItems'.
+func FindFereignItemsByItem(ctx context.Context, db YODB, i *Item) ([]*FereignItem, error) {
+	const sqlstr = "SELECT " +
+		"ID, ItemID, Category " +
+		"FROM FereignItems " +
+		"WHERE ItemID = @param0"
+
+	stmt := spanner.NewStatement(sqlstr)
+	stmt.Params["param0"] = yoEncode(i.ID)
+
+	decoder := newFereignItem_Decoder(FereignItemColumns())
+
+	// run query
+	YOLog(ctx, sqlstr, i.ID)
+	iter := db.Query(ctx, stmt)
+	defer iter.Stop()
+
+	// load results
+	res := []*FereignItem{}
+	for {
+		row, err := iter.Next()
+		if err != nil {
+			if err == iterator.Done {
+				break
+			}
+			return nil, newError("FindFereignItemsByItem", "FereignItems", err)
+		}
+
+		fi, err := decoder(row)
+		if err != nil {
+			return nil, newErrorWithCode(codes.Internal, "FindFereignItemsByItem", "FereignItems", err)
+		}
+
+		res = append(res, fi)
+	}
+
+	return res, nil
+}
@@ -6,6 +6,7 @@ package models
 import (
 	"context"
 	"fmt"
+	"unicode/utf8"
 
 	"cloud.google.com/go/spanner"
 	"google.golang.org/grpc/codes"
@@ -99,6 +100,35 @@ func newGeneratedColumn_Decoder(cols []string) func(*spanner.Row) (*GeneratedCol
 	}
 }
 
+// Validate checks the values of GeneratedColumn against the NOT NULL, the length
+// and the CHECK constraints of 'GeneratedColumns' before writing them. CHECK
+// constraints that cannot be translated into Go are left to Spanner.
+func (gc *GeneratedColumn) Validate() error {
+	return gc.validateColumns(GeneratedColumnWritableColumns())
+}
+
+// validateColumns checks the values of the columns in cols in the same way as
+// Validate. The constraints on the other columns, such as the columns left to
+// their DEFAULT values, are not checked.
+func (gc *GeneratedColumn) validateColumns(cols []string) error {
+	written := make(map[string]bool, len(cols))
+	for _, col := range cols {
+		written[col] = true
+	}
+
+	if written["FirstName"] {
+		if utf8.RuneCountInString(gc.FirstName) > 50 {
+			return newErrorWithCode(codes.InvalidArgument, "GeneratedColumn.Validate", "GeneratedColumns", fmt.Errorf("FirstName must be at most 50 characters"))
+		}
+	}
+	if written["LastName"] {
+		if utf8.RuneCountInString(gc.LastName) > 50 {
+			return newErrorWithCode(codes.InvalidArgument, "GeneratedColumn.Validate", "GeneratedColumns", fmt.Errorf("LastName must be at most 50 characters"))
+		}
+	}
+	return nil
+}
+
 // Insert returns a Mutation to insert a row into a table. If the row already
 // exists, the write or transaction fails.
 func (gc *GeneratedColumn) Insert(ctx context.Context) *spanner.Mutation {
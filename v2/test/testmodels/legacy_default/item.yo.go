@@ -86,6 +86,20 @@ func newItem_Decoder(cols []string) func(*spanner.Row) (*Item, error) {
 	}
 }
 
+// Validate checks the values of Item against the NOT NULL, the length
+// and the CHECK constraints of 'Items' before writing them. CHECK
+// constraints that cannot be translated into Go are left to Spanner.
+func (i *Item) Validate() error {
+	return i.validateColumns(ItemWritableColumns())
+}
+
+// validateColumns checks the values of the columns in cols in the same way as
+// Validate. The constraints on the other columns, such as the columns left to
+// their DEFAULT values, are not checked.
+func (i *Item) validateColumns(cols []string) error {
+	return nil
+}
+
 // Insert returns a Mutation to insert a row into a table. If the row already
 // exists, the write or transaction fails.
 func (i *Item) Insert(ctx context.Context) *spanner.Mutation {
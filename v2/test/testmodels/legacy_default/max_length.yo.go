@@ -86,6 +86,30 @@ func newMaxLength_Decoder(cols []string) func(*spanner.Row) (*MaxLength, error)
 	}
 }
 
+// Validate checks the values of MaxLength against the NOT NULL, the length
+// and the CHECK constraints of 'MaxLengths' before writing them. CHECK
+// constraints that cannot be translated into Go are left to Spanner.
+func (ml *MaxLength) Validate() error {
+	return ml.validateColumns(MaxLengthWritableColumns())
+}
+
+// validateColumns checks the values of the columns in cols in the same way as
+// Validate. The constraints on the other columns, such as the columns left to
+// their DEFAULT values, are not checked.
+func (ml *MaxLength) validateColumns(cols []string) error {
+	written := make(map[string]bool, len(cols))
+	for _, col := range cols {
+		written[col] = true
+	}
+
+	if written["MaxBytes"] {
+		if ml.MaxBytes == nil {
+			return newErrorWithCode(codes.InvalidArgument, "MaxLength.Validate", "MaxLengths", fmt.Errorf("MaxBytes must not be NULL"))
+		}
+	}
+	return nil
+}
+
 // Insert returns a Mutation to insert a row into a table. If the row already
 // exists, the write or transaction fails.
 func (ml *MaxLength) Insert(ctx context.Context) *spanner.Mutation {
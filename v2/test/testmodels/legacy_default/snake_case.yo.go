@@ -6,6 +6,7 @@ package models
 import (
 	"context"
 	"fmt"
+	"unicode/utf8"
 
 	"cloud.google.com/go/spanner"
 	"google.golang.org/api/iterator"
@@ -94,6 +95,30 @@ func newSnakeCase_Decoder(cols []string) func(*spanner.Row) (*SnakeCase, error)
 	}
 }
 
+// Validate checks the values of SnakeCase against the NOT NULL, the length
+// and the CHECK constraints of 'snake_cases' before writing them. CHECK
+// constraints that cannot be translated into Go are left to Spanner.
+func (sc *SnakeCase) Validate() error {
+	return sc.validateColumns(SnakeCaseWritableColumns())
+}
+
+// validateColumns checks the values of the columns in cols in the same way as
+// Validate. The constraints on the other columns, such as the columns left to
+// their DEFAULT values, are not checked.
+func (sc *SnakeCase) validateColumns(cols []string) error {
+	written := make(map[string]bool, len(cols))
+	for _, col := range cols {
+		written[col] = true
+	}
+
+	if written["string_id"] {
+		if utf8.RuneCountInString(sc.StringID) > 32 {
+			return newErrorWithCode(codes.InvalidArgument, "SnakeCase.Validate", "snake_cases", fmt.Errorf("string_id must be at most 32 characters"))
+		}
+	}
+	return nil
+}
+
 // Insert returns a Mutation to insert a row into a table. If the row already
 // exists, the write or transaction fails.
 func (sc *SnakeCase) Insert(ctx context.Context) *spanner.Mutation {
@@ -190,7 +215,8 @@ func FindSnakeCasesByStringIDFooBarBaz(ctx context.Context, db YODB, stringID st
 	const sqlstr = "SELECT " +
 		"id, string_id, foo_bar_baz " +
 		"FROM snake_cases@{FORCE_INDEX=snake_cases_by_string_id} " +
-		"WHERE string_id = @param0 AND foo_bar_baz = @param1"
+		"WHERE string_id = @param0 AND foo_bar_baz = @param1" +
+		" ORDER BY id"
 
 	stmt := spanner.NewStatement(sqlstr)
 	stmt.Params["param0"] = yoEncode(stringID)
@@ -75,6 +75,13 @@ func DeleteAllData(ctx context.Context, client *spanner.Client) error {
 		"FereignItems",
 		"GeneratedColumns",
 		"Inflectionzz",
+		"ChildItems",
+		"ParentItems",
+		"DefaultValues",
+		"CommitTimestamps",
+		"CheckedItems",
+		"ExpiringItems",
+		"ScoredItems",
 	}
 	var muts []*spanner.Mutation
 	for _, table := range tables {
@@ -89,6 +96,16 @@ func DeleteAllData(ctx context.Context, client *spanner.Client) error {
 }
 
 func SetupDatabase(ctx context.Context, projectName, instanceName, dbName string, schema string) error {
+	return setupDatabase(ctx, projectName, instanceName, dbName, schema, dbadminpb.DatabaseDialect_GOOGLE_STANDARD_SQL)
+}
+
+// SetupPostgreSQLDatabase creates a PostgreSQL-dialect database with the
+// schema in the emulator. The database is recreated if it exists.
+func SetupPostgreSQLDatabase(ctx context.Context, projectName, instanceName, dbName string, schema string) error {
+	return setupDatabase(ctx, projectName, instanceName, dbName, schema, dbadminpb.DatabaseDialect_POSTGRESQL)
+}
+
+func setupDatabase(ctx context.Context, projectName, instanceName, dbName string, schema string, dialect dbadminpb.DatabaseDialect) error {
 	if v := os.Getenv("SPANNER_EMULATOR_HOST"); v == "" {
 		return fmt.Errorf("test must use spanner emulator")
 	}
@@ -122,7 +139,7 @@ func SetupDatabase(ctx context.Context, projectName, instanceName, dbName string
 		}
 	}
 
-	if err := CreateDatabase(ctx, dbAdminCli, projectName, instanceName, dbName); err != nil {
+	if err := createDatabase(ctx, dbAdminCli, projectName, instanceName, dbName, dialect); err != nil {
 		return err
 	}
 
@@ -182,9 +199,19 @@ func CreateInstance(ctx context.Context, insAdminCli *insadmin.InstanceAdminClie
 }
 
 func CreateDatabase(ctx context.Context, dbAdminCli *dbadmin.DatabaseAdminClient, projectName, instanceName, dbName string) error {
+	return createDatabase(ctx, dbAdminCli, projectName, instanceName, dbName, dbadminpb.DatabaseDialect_GOOGLE_STANDARD_SQL)
+}
+
+func createDatabase(ctx context.Context, dbAdminCli *dbadmin.DatabaseAdminClient, projectName, instanceName, dbName string, dialect dbadminpb.DatabaseDialect) error {
+	createStatement := fmt.Sprintf("CREATE DATABASE `%s`", dbName)
+	if dialect == dbadminpb.DatabaseDialect_POSTGRESQL {
+		createStatement = fmt.Sprintf(`CREATE DATABASE "%s"`, dbName)
+	}
+
 	dbOp, err := dbAdminCli.CreateDatabase(ctx, &dbadminpb.CreateDatabaseRequest{
 		Parent:          fmt.Sprintf("projects/%s/instances/%s", projectName, instanceName),
-		CreateStatement: fmt.Sprintf("CREATE DATABASE `%s`", dbName),
+		CreateStatement: createStatement,
+		DatabaseDialect: dialect,
 	})
 	if err != nil {
 		return fmt.Errorf("failed to create database operation: %v", err)